@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration a time.Duration that is represented as a human readable string (e.g. "1m30s") in spec files.
+type Duration time.Duration
+
+// Duration returns the value of this duration as a time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String returns the human readable representation of this duration
+func (d Duration) String() string {
+	return d.Duration().String()
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Accepts duration strings and nanosecond numbers.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	return d.set(value)
+}
+
+// MarshalYAML implements yaml.Marshaler
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Accepts duration strings and nanosecond numbers.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	return d.set(value)
+}
+
+func (d *Duration) set(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+
+	case int:
+		*d = Duration(v)
+
+	case float64:
+		*d = Duration(int64(v))
+
+	default:
+		return fmt.Errorf("invalid duration value '%v'", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type durationHolder struct {
+	Value Duration `json:"value" yaml:"value"`
+}
+
+func TestDurationYamlRoundTrip(t *testing.T) {
+	var holder durationHolder
+
+	assert.NoError(t, yaml.Unmarshal([]byte("value: 1m30s"), &holder))
+	assert.Equal(t, 90*time.Second, holder.Value.Duration())
+
+	data, err := yaml.Marshal(holder)
+	assert.NoError(t, err)
+	assert.Equal(t, "value: 1m30s\n", string(data))
+}
+
+func TestDurationJSONRoundTrip(t *testing.T) {
+	var holder durationHolder
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"value": "250ms"}`), &holder))
+	assert.Equal(t, 250*time.Millisecond, holder.Value.Duration())
+
+	data, err := json.Marshal(holder)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"value":"250ms"}`, string(data))
+}
+
+func TestDurationFromNanos(t *testing.T) {
+	var holder durationHolder
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"value": 1000}`), &holder))
+	assert.Equal(t, time.Microsecond, holder.Value.Duration())
+
+	assert.NoError(t, yaml.Unmarshal([]byte("value: 1000"), &holder))
+	assert.Equal(t, time.Microsecond, holder.Value.Duration())
+}
+
+func TestDurationWithInvalidValue(t *testing.T) {
+	var holder durationHolder
+
+	assert.Error(t, yaml.Unmarshal([]byte("value: 2 seconds"), &holder))
+	assert.Error(t, json.Unmarshal([]byte(`{"value": true}`), &holder))
+}
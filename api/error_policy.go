@@ -0,0 +1,15 @@
+package api
+
+// ErrorPolicy determines how the failure of a command affects the benchmark
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyIgnore failures are not reported and don't affect the outcome of the benchmark
+	ErrorPolicyIgnore ErrorPolicy = "ignore"
+	// ErrorPolicyWarn failures are reported and the benchmark continues. This is the default policy.
+	ErrorPolicyWarn ErrorPolicy = "warn"
+	// ErrorPolicySkipScenario failures are reported and the remaining executions of the scenario are skipped
+	ErrorPolicySkipScenario ErrorPolicy = "skip-scenario"
+	// ErrorPolicyAbort failures are reported and the benchmark is stopped
+	ErrorPolicyAbort ErrorPolicy = "abort"
+)
@@ -2,15 +2,37 @@ package api
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrTimeout the error executions that exceed their timeout fail with. Might be wrapped.
+var ErrTimeout = errors.New("timed out")
+
 // ExecutionInfo information about an executed command
 type ExecutionInfo struct {
 	UserTime      time.Duration
 	SystemTime    time.Duration
 	PerceivedTime time.Duration
 	ExitCode      int
+	// Concurrency the number of benchmarked commands that were in progress when this command started, including itself
+	Concurrency   int
+	ResourceUsage ResourceUsage
+	// Wait the time the command waited for the load of the host to drop before it started
+	Wait time.Duration
+}
+
+// ResourceUsage resource usage information about an executed command, as reported by the operating system.
+// Metrics that are not supported by the operating system are reported as zero.
+type ResourceUsage struct {
+	// MaxRSS the peak resident set size in bytes
+	MaxRSS                     int64 `json:"maxRSS,omitempty"`
+	MinorPageFaults            int64 `json:"minorPageFaults,omitempty"`
+	MajorPageFaults            int64 `json:"majorPageFaults,omitempty"`
+	VoluntaryContextSwitches   int64 `json:"voluntaryContextSwitches,omitempty"`
+	InvoluntaryContextSwitches int64 `json:"involuntaryContextSwitches,omitempty"`
+	BlockInputOps              int64 `json:"blockInputOps,omitempty"`
+	BlockOutputOps             int64 `json:"blockOutputOps,omitempty"`
 }
 
 // ExecCommandFn executes a command and returns execution information or an error
@@ -27,6 +49,8 @@ type ExecutionContext struct {
 	Executor CommandExecutor
 	Tracer   Tracer
 	Listener
+	// Metadata the metadata of the run, which the execution records the scenarios it stopped in. Might be nil.
+	Metadata *RunMetadata
 }
 
 // NewExecutionContext creates a new ExecutionContext.
@@ -0,0 +1,25 @@
+package api
+
+// ExecutionOrder determines the order in which the executions of the scenarios of a benchmark are scheduled
+type ExecutionOrder string
+
+const (
+	// OrderSequential all the executions of a scenario are completed before the next scenario starts. This is the default order.
+	OrderSequential ExecutionOrder = "sequential"
+	// OrderAlternate scenarios are executed in round-robin, one execution at a time
+	OrderAlternate ExecutionOrder = "alternate"
+	// OrderRandom scenarios are executed in rounds of one execution per scenario, each round in a random order
+	OrderRandom ExecutionOrder = "random"
+	// OrderShuffle the executions of all the scenarios are executed in a random order
+	OrderShuffle ExecutionOrder = "shuffle"
+)
+
+// HasRounds returns true if this order executes the scenarios in rounds of one execution per scenario
+func (o ExecutionOrder) HasRounds() bool {
+	return o == OrderAlternate || o == OrderRandom
+}
+
+// IsRandom returns true if this order is randomized
+func (o ExecutionOrder) IsRandom() bool {
+	return o == OrderRandom || o == OrderShuffle
+}
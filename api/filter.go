@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScenarioFilter selects the scenarios of a benchmark to execute.
+// A scenario is selected if it matches any of the 'Only' patterns and has any of the 'Tags', and doesn't match any of
+// the 'Skip' patterns. Empty 'Only' and 'Tags' lists select all scenarios.
+type ScenarioFilter struct {
+	// Only names or patterns of the scenarios to select. Patterns are either globs or regular expressions wrapped in slashes.
+	Only []string `json:"only,omitempty"`
+	// Skip names or patterns of the scenarios to exclude
+	Skip []string `json:"skip,omitempty"`
+	// Tags the tags of the scenarios to select
+	Tags []string `json:"tags,omitempty"`
+}
+
+// IsEmpty returns true if this filter selects all scenarios.
+func (f ScenarioFilter) IsEmpty() bool {
+	return len(f.Only) == 0 && len(f.Skip) == 0 && len(f.Tags) == 0
+}
+
+func (f ScenarioFilter) String() string {
+	var parts []string
+	for _, part := range []struct {
+		name   string
+		values []string
+	}{{"only", f.Only}, {"tags", f.Tags}, {"skip", f.Skip}} {
+		if len(part.values) > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%s", part.name, strings.Join(part.values, ",")))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -1,11 +1,19 @@
 package api
 
-// Listener a listener for benchmark progress events
+// Listener a listener for benchmark progress events.
+// When a benchmark runs with a concurrency level greater than 1, events are reported from multiple goroutines,
+// so implementations must be safe for concurrent use.
 type Listener interface {
 	OnBenchmarkStart()
 	OnBenchmarkEnd()
 	OnScenarioStart(id ID)
 	OnScenarioEnd(id ID)
+	OnWarmupStart(id ID)
+	OnWarmupEnd(id ID)
+	// OnExecutionsEstimate reports an updated estimate of the total number of executions of a scenario.
+	// Reported in adaptive execution mode, in which the number of executions is not known in advance,
+	// and when the remaining executions of a scenario are skipped due to a failure.
+	OnExecutionsEstimate(id ID, executions int)
 	OnMessagef(id ID, format string, args ...interface{})
 	OnMessage(id ID, message string)
 	OnError(id ID, err error)
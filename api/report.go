@@ -5,6 +5,15 @@ type ReportContext struct {
 	Labels         []string
 	IncludeHeaders bool
 	UTCDate        bool
+	Baseline       ID
+	// RemoveOutliers whether outliers should be excluded from the mean, stddev and percentile statistics
+	RemoveOutliers bool
+	// Filter the filter that selected the scenarios of the benchmark
+	Filter ScenarioFilter
+	// Metadata information about the benchmark run and the host it was executed on. Completed when the run ends. Might be nil.
+	Metadata *RunMetadata
+	// BaselineSummary the summary of saved baseline results, used to evaluate regression thresholds. Might be nil.
+	BaselineSummary Summary
 }
 
 // WriteSummaryReportFn a benchmark report handler
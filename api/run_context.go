@@ -0,0 +1,26 @@
+package api
+
+import "context"
+
+// RunContext identifies the scenario run a command is executed as part of
+type RunContext struct {
+	Scenario ID
+	// Index the 1-based index of the run. Zero for commands that are not part of a measured run, such as 'beforeAll' and warmup runs.
+	Index int
+	// Total the max number of runs of the scenario
+	Total int
+}
+
+type runContextKey struct{}
+
+// WithRunContext returns a copy of the specified context that carries the specified run context.
+func WithRunContext(ctx context.Context, run RunContext) context.Context {
+	return context.WithValue(ctx, runContextKey{}, run)
+}
+
+// RunContextOf returns the run context carried by the specified context, or false if it doesn't carry one.
+func RunContextOf(ctx context.Context) (RunContext, bool) {
+	run, ok := ctx.Value(runContextKey{}).(RunContext)
+
+	return run, ok
+}
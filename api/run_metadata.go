@@ -0,0 +1,70 @@
+package api
+
+import (
+	"time"
+)
+
+// RunMetadata information about a benchmark run and the conditions it was executed in
+type RunMetadata struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Duration the total duration of the run
+	Duration Duration `json:"duration"`
+	Host     HostInfo `json:"host"`
+	// Version the version of bert that executed the run
+	Version string `json:"version,omitempty"`
+	// Build the build label of bert that executed the run
+	Build string `json:"build,omitempty"`
+	// Flags the values of the command line flags that were specified for the run, by name. Flags that were specified
+	// more than once, or with a list of values, have a value per item. The values of variables are redacted.
+	Flags map[string][]string `json:"flags,omitempty"`
+	// Stopped the scenarios that were stopped before they were complete, because the max time of the run was spent
+	Stopped []StoppedScenario `json:"stopped,omitempty"`
+}
+
+// StoppedScenario a scenario that was stopped before it was complete, because the max time of the run was spent
+type StoppedScenario struct {
+	ID ID `json:"id"`
+	// Executions the number of executions of the scenario that were completed
+	Executions int `json:"executions"`
+	// MaxExecutions the number of executions the scenario would have been executed otherwise, at most
+	MaxExecutions int `json:"maxExecutions"`
+}
+
+// HostInfo information about the host a benchmark was executed on. Values that are not available on the host are empty.
+type HostInfo struct {
+	Hostname string `json:"hostname,omitempty"`
+	OS       string `json:"os,omitempty"`
+	// Kernel the kernel release of the host
+	Kernel   string `json:"kernel,omitempty"`
+	Arch     string `json:"arch,omitempty"`
+	CPUModel string `json:"cpuModel,omitempty"`
+	CPUs     int    `json:"cpus,omitempty"`
+	// Memory the total physical memory of the host in bytes
+	Memory uint64 `json:"memory,omitempty"`
+	// LoadAverage the 1, 5 and 15 minute load averages of the host when the run started
+	LoadAverage []float64 `json:"loadAverage,omitempty"`
+	// CPUGovernor the CPU frequency scaling governor of the host
+	CPUGovernor string `json:"cpuGovernor,omitempty"`
+}
+
+// StoppedScenario returns the specified scenario if it was stopped before it was complete, or false otherwise.
+// Safe to call on a nil RunMetadata.
+func (m *RunMetadata) StoppedScenario(id ID) (StoppedScenario, bool) {
+	if m == nil {
+		return StoppedScenario{}, false
+	}
+	for _, stopped := range m.Stopped {
+		if stopped.ID == id {
+			return stopped, true
+		}
+	}
+
+	return StoppedScenario{}, false
+}
+
+// End records the end of the run at the specified time.
+func (m *RunMetadata) End(t time.Time) {
+	m.EndTime = t
+	m.Duration = Duration(t.Sub(m.StartTime))
+}
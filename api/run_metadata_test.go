@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMetadataStoppedScenario(t *testing.T) {
+	metadata := &RunMetadata{Stopped: []StoppedScenario{{ID: "a", Executions: 3, MaxExecutions: 5}}}
+
+	stopped, ok := metadata.StoppedScenario("a")
+	assert.True(t, ok)
+	assert.Equal(t, 3, stopped.Executions)
+
+	_, ok = metadata.StoppedScenario("b")
+	assert.False(t, ok)
+
+	_, ok = (*RunMetadata)(nil).StoppedScenario("a")
+	assert.False(t, ok)
+}
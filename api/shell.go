@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultShell the shell commands are executed with when their shell is set to 'true'
+const DefaultShell Shell = "sh"
+
+// Shell the shell a command is executed with, using '<shell> -c <command>'.
+// An empty shell means that the command is executed directly. In spec files, 'true' stands for the default shell.
+type Shell string
+
+// UnmarshalJSON implements json.Unmarshaler. Accepts shell names and booleans.
+func (s *Shell) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	return s.set(value)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Accepts shell names and booleans.
+func (s *Shell) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	return s.set(value)
+}
+
+func (s *Shell) set(value interface{}) error {
+	switch v := value.(type) {
+	case bool:
+		*s = ""
+		if v {
+			*s = DefaultShell
+		}
+
+	case string:
+		*s = Shell(v)
+
+	case nil:
+		*s = ""
+
+	default:
+		return fmt.Errorf("invalid shell value '%v'", value)
+	}
+
+	return nil
+}
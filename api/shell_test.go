@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type shellHolder struct {
+	Value Shell `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+func TestShellFromYaml(t *testing.T) {
+	examples := map[string]Shell{
+		"value: true":  DefaultShell,
+		"value: false": "",
+		"value: bash":  "bash",
+	}
+
+	for example, expected := range examples {
+		var holder shellHolder
+
+		assert.NoError(t, yaml.Unmarshal([]byte(example), &holder))
+		assert.Equal(t, expected, holder.Value, example)
+	}
+}
+
+func TestShellFromJSON(t *testing.T) {
+	examples := map[string]Shell{
+		`{"value": true}`:       DefaultShell,
+		`{"value": false}`:      "",
+		`{"value": "/bin/zsh"}`: "/bin/zsh",
+	}
+
+	for example, expected := range examples {
+		var holder shellHolder
+
+		assert.NoError(t, json.Unmarshal([]byte(example), &holder))
+		assert.Equal(t, expected, holder.Value, example)
+	}
+}
+
+func TestShellYamlRoundTrip(t *testing.T) {
+	data, err := yaml.Marshal(shellHolder{Value: "bash"})
+	assert.NoError(t, err)
+	assert.Equal(t, "value: bash\n", string(data))
+
+	var holder shellHolder
+	assert.NoError(t, yaml.Unmarshal(data, &holder))
+	assert.Equal(t, Shell("bash"), holder.Value)
+}
+
+func TestInvalidShell(t *testing.T) {
+	var holder shellHolder
+
+	assert.Error(t, yaml.Unmarshal([]byte("value: [sh]"), &holder))
+	assert.Error(t, json.Unmarshal([]byte(`{"value": 1}`), &holder))
+}
@@ -1,32 +1,270 @@
 package api
 
+import "time"
+
 // CommandSpec benchmark command execution specs
 type CommandSpec struct {
 	WorkingDirectory string   `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
 	Cmd              []string `json:"cmd" yaml:"cmd" validate:"required"`
+	// Timeout the maximum duration of the command. The command and all of its child processes are killed when it is exceeded.
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" validate:"gte=0"`
+	// Shell the shell to run the command with. The command line arguments are joined with spaces and passed to '<shell> -c'.
+	Shell Shell `json:"shell,omitempty" yaml:"shell,omitempty"`
+	// OnError the policy to apply when the command fails. Defaults to 'warn', or to 'abort' when the benchmark fails fast.
+	OnError ErrorPolicy `json:"onError,omitempty" yaml:"onError,omitempty" validate:"omitempty,oneof=ignore warn skip-scenario abort"`
+	// Retries the number of times to retry the command before its failure is handled. Only supported for hooks.
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty" validate:"gte=0"`
+	// Expect the expected outcome of the command. By default, a command is expected to exit with code 0.
+	Expect *ExpectSpec `json:"expect,omitempty" yaml:"expect,omitempty"`
+	// Stdin the path of a file to feed to the standard input of each execution. Relative paths are relative to the working directory of the command.
+	Stdin string `json:"stdin,omitempty" yaml:"stdin,omitempty"`
+}
+
+// ExpectSpec the expected outcome of a command. Executions that don't meet any of the expectations fail.
+// Output patterns are regular expressions that are matched against the entire output stream of the command.
+type ExpectSpec struct {
+	// ExitCode the accepted exit codes. Defaults to 0.
+	ExitCode         []int  `json:"exitCode,omitempty" yaml:"exitCode,omitempty"`
+	StdoutMatches    string `json:"stdoutMatches,omitempty" yaml:"stdoutMatches,omitempty"`
+	StdoutNotMatches string `json:"stdoutNotMatches,omitempty" yaml:"stdoutNotMatches,omitempty"`
+	StderrMatches    string `json:"stderrMatches,omitempty" yaml:"stderrMatches,omitempty"`
+	StderrNotMatches string `json:"stderrNotMatches,omitempty" yaml:"stderrNotMatches,omitempty"`
 }
 
 // ScenarioSpec benchmark scenario specs
 type ScenarioSpec struct {
 	Name             string            `json:"name" yaml:"name" validate:"required"`
+	WorkingDirectory string            `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
+	Env              map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	Warmup           *int              `json:"warmup,omitempty" yaml:"warmup,omitempty" validate:"omitempty,gte=0"`
+	BeforeAll        *CommandSpec      `json:"beforeAll,omitempty" yaml:"beforeAll,omitempty"`
+	AfterAll         *CommandSpec      `json:"afterAll,omitempty" yaml:"afterAll,omitempty"`
+	BeforeEach       *CommandSpec      `json:"beforeEach,omitempty" yaml:"beforeEach,omitempty"`
+	AfterEach        *CommandSpec      `json:"afterEach,omitempty" yaml:"afterEach,omitempty"`
+	Command          *CommandSpec      `json:"command" yaml:"command" validate:"required"`
+	Thresholds       *ThresholdsSpec   `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	// Parameters a matrix of parameter values. A scenario with parameters is expanded into one scenario per combination of values.
+	Parameters map[string][]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// ParameterValues the parameter values of a scenario that has been expanded from a parameter matrix
+	ParameterValues map[string]string `json:"parameterValues,omitempty" yaml:"parameterValues,omitempty"`
+	// MaxErrors the number of reported errors after which the remaining executions of the scenario are skipped. Zero means no limit.
+	MaxErrors int `json:"maxErrors,omitempty" yaml:"maxErrors,omitempty" validate:"gte=0"`
+	// Tags labels that can be used to select the scenario to execute
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Isolation operating system settings applied to the benchmarked command of the scenario
+	Isolation *IsolationSpec `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+	// Extends the name of a scenario this scenario inherits the values it doesn't set from
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// Template whether this scenario is only a base for other scenarios to extend. Templates are not executed.
+	Template bool `json:"template,omitempty" yaml:"template,omitempty"`
+	// Executions the number of executions of the scenario. Takes precedence over the benchmark level value and adaptive execution.
+	Executions int `json:"executions,omitempty" yaml:"executions,omitempty" validate:"gte=0"`
+	// MaxDuration a wall-clock budget for the executions of the scenario. Takes precedence over the adaptive execution budget.
+	MaxDuration Duration `json:"maxDuration,omitempty" yaml:"maxDuration,omitempty" validate:"gte=0"`
+}
+
+// DefaultsSpec values that are inherited by every scenario of a benchmark that doesn't set its own
+type DefaultsSpec struct {
 	WorkingDirectory string            `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
 	Env              map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
 	BeforeAll        *CommandSpec      `json:"beforeAll,omitempty" yaml:"beforeAll,omitempty"`
 	AfterAll         *CommandSpec      `json:"afterAll,omitempty" yaml:"afterAll,omitempty"`
 	BeforeEach       *CommandSpec      `json:"beforeEach,omitempty" yaml:"beforeEach,omitempty"`
 	AfterEach        *CommandSpec      `json:"afterEach,omitempty" yaml:"afterEach,omitempty"`
-	Command          *CommandSpec      `validate:"required,dive"`
+	Isolation        *IsolationSpec    `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+}
+
+// IsolationSpec operating system settings applied to the processes of a benchmarked command. Only supported on Linux.
+type IsolationSpec struct {
+	// CPUs the CPUs the command is allowed to run on
+	CPUs []int `json:"cpus,omitempty" yaml:"cpus,omitempty" validate:"dive,gte=0"`
+	// Nice the scheduling priority of the command, from -20 (highest) to 19 (lowest). Negative values require privileges.
+	Nice *int `json:"nice,omitempty" yaml:"nice,omitempty" validate:"omitempty,gte=-20,lte=19"`
+	// IONice the best-effort I/O scheduling priority of the command, from 0 (highest) to 7 (lowest)
+	IONice *int `json:"ioNice,omitempty" yaml:"ioNice,omitempty" validate:"omitempty,gte=0,lte=7"`
+	// Rlimits resource limits by resource name, e.g. 'nofile'. Both the soft and the hard limits are set.
+	Rlimits map[string]uint64 `json:"rlimits,omitempty" yaml:"rlimits,omitempty" validate:"dive,keys,oneof=as core cpu data fsize memlock nofile nproc stack,endkeys"`
+}
+
+// ThresholdsSpec performance assertions for a scenario. Unset values are not asserted.
+type ThresholdsSpec struct {
+	Mean       Duration                  `json:"mean,omitempty" yaml:"mean,omitempty" validate:"gte=0"`
+	Median     Duration                  `json:"median,omitempty" yaml:"median,omitempty" validate:"gte=0"`
+	P90        Duration                  `json:"p90,omitempty" yaml:"p90,omitempty" validate:"gte=0"`
+	Max        Duration                  `json:"max,omitempty" yaml:"max,omitempty" validate:"gte=0"`
+	ErrorRate  *float64                  `json:"errorRate,omitempty" yaml:"errorRate,omitempty" validate:"omitempty,gte=0,lte=100"`
+	Regression *RegressionThresholdsSpec `json:"regression,omitempty" yaml:"regression,omitempty"`
+}
+
+// RegressionThresholdsSpec the maximum allowed regression of each metric in percents, relative to saved baseline results.
+type RegressionThresholdsSpec struct {
+	Mean   *float64 `json:"mean,omitempty" yaml:"mean,omitempty" validate:"omitempty,gte=0"`
+	Median *float64 `json:"median,omitempty" yaml:"median,omitempty" validate:"omitempty,gte=0"`
+	P90    *float64 `json:"p90,omitempty" yaml:"p90,omitempty" validate:"omitempty,gte=0"`
 }
 
 // BenchmarkSpec benchmark specs top level structure
 type BenchmarkSpec struct {
-	Scenarios  []ScenarioSpec `json:"scenarios" yaml:"scenarios" validate:"required,min=1"`
-	Executions int            `validate:"required,gte=1"`
-	Alternate  bool           `json:"alternate,omitempty" yaml:"alternate,omitempty"`
-	FailFast   bool           `json:"failFast,omitempty" yaml:"failFast,omitempty"`
+	Scenarios   []ScenarioSpec `json:"scenarios" yaml:"scenarios" validate:"required,min=1,dive"`
+	Executions  int            `json:"executions,omitempty" yaml:"executions,omitempty" validate:"gte=0"`
+	Adaptive    *AdaptiveSpec  `json:"adaptive,omitempty" yaml:"adaptive,omitempty"`
+	Warmup      int            `json:"warmup,omitempty" yaml:"warmup,omitempty" validate:"gte=0"`
+	Alternate   bool           `json:"alternate,omitempty" yaml:"alternate,omitempty"`
+	Concurrency int            `json:"concurrency,omitempty" yaml:"concurrency,omitempty" validate:"gte=0"`
+	FailFast    bool           `json:"failFast,omitempty" yaml:"failFast,omitempty"`
+	Percentiles []float64      `json:"percentiles,omitempty" yaml:"percentiles,omitempty" validate:"dive,gt=0,lte=100"`
+	// Include paths of files to load more scenarios from. Relative paths are relative to the including file.
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+	// Defaults values inherited by all scenarios that don't set their own
+	Defaults *DefaultsSpec `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	// Setup a command that is executed once, before any of the scenarios starts
+	Setup *CommandSpec `json:"setup,omitempty" yaml:"setup,omitempty"`
+	// Teardown a command that is executed once, after all the scenarios are done. Executed even if the benchmark is aborted.
+	Teardown *CommandSpec `json:"teardown,omitempty" yaml:"teardown,omitempty"`
+	// BeforeRound a command that is executed before each round of executions. Only applies to orders that execute scenarios in rounds.
+	BeforeRound *CommandSpec `json:"beforeRound,omitempty" yaml:"beforeRound,omitempty"`
+	// AfterRound a command that is executed once all the executions of a round are done. Only applies to orders that execute scenarios in rounds.
+	AfterRound *CommandSpec `json:"afterRound,omitempty" yaml:"afterRound,omitempty"`
+	// Vars variables that can be referenced as '${name}' in commands, environment variables and working directories
+	Vars map[string]string `json:"vars,omitempty" yaml:"vars,omitempty"`
+	// OutputDir a directory for commands to write files to. Commands receive it in the 'BERT_OUTPUT_DIR' variable.
+	// Defaults to the current working directory.
+	OutputDir string `json:"outputDir,omitempty" yaml:"outputDir,omitempty"`
+	// CaptureOutput a directory to save the standard output and error of each measured run of the benchmarked commands to,
+	// as '<scenario>/run-<index>.out' and '<scenario>/run-<index>.err'.
+	CaptureOutput string `json:"captureOutput,omitempty" yaml:"captureOutput,omitempty"`
+	// Timeout the default timeout of commands that don't specify one
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" validate:"gte=0"`
+	// Order the order in which scenario executions are scheduled. Takes precedence over Alternate.
+	Order ExecutionOrder `json:"order,omitempty" yaml:"order,omitempty" validate:"omitempty,oneof=sequential alternate random shuffle"`
+	// Seed the seed of random execution orders. Runs with the same seed and spec are executed in the same order.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+	// MaxTime a wall-clock budget for the whole benchmark. Once it is spent, no more executions are started.
+	MaxTime Duration `json:"maxTime,omitempty" yaml:"maxTime,omitempty" validate:"gte=0"`
+	// MaxLoad the 1 minute load average of the host below which measured runs start. Runs wait for the load to drop
+	// below it for up to Settle. Zero disables waiting.
+	MaxLoad float64 `json:"maxLoad,omitempty" yaml:"maxLoad,omitempty" validate:"gte=0"`
+	// Settle the maximum time a measured run waits for the load of the host to drop below MaxLoad. Defaults to DefaultSettle.
+	Settle Duration `json:"settle,omitempty" yaml:"settle,omitempty" validate:"gte=0"`
+	// SubtractShellOverhead whether to subtract the startup time of shells from the measurements of benchmarked commands that run in a shell
+	SubtractShellOverhead bool `json:"subtractShellOverhead,omitempty" yaml:"subtractShellOverhead,omitempty"`
+}
+
+// DefaultPercentiles the percentiles included in summary reports when none are specified
+var DefaultPercentiles = []float64{90}
+
+// DefaultSettle the maximum time measured runs wait for the load of the host to drop when no settle time is specified
+const DefaultSettle = Duration(time.Minute)
+
+// AdaptiveSpec adaptive execution mode specs.
+// In this mode each scenario is executed until the relative standard error of its mean reaches the target,
+// its time budget is spent, or the max number of executions is reached, whichever comes first.
+type AdaptiveSpec struct {
+	MinExecutions int      `json:"minExecutions" yaml:"minExecutions" validate:"gte=2"`
+	MaxExecutions int      `json:"maxExecutions" yaml:"maxExecutions" validate:"gtefield=MinExecutions"`
+	TargetRSE     float64  `json:"targetRSE,omitempty" yaml:"targetRSE,omitempty" validate:"gte=0"`
+	MaxDuration   Duration `json:"maxDuration,omitempty" yaml:"maxDuration,omitempty" validate:"gte=0"`
 }
 
 // ID returns a unique identifier
 func (s ScenarioSpec) ID() string {
 	return s.Name
 }
+
+// ReportPercentiles returns the percentiles to include in summary reports.
+func (spec BenchmarkSpec) ReportPercentiles() []float64 {
+	if len(spec.Percentiles) > 0 {
+		return spec.Percentiles
+	}
+
+	return DefaultPercentiles
+}
+
+// ExecutionOrder returns the order in which scenario executions are scheduled.
+func (spec BenchmarkSpec) ExecutionOrder() ExecutionOrder {
+	switch {
+	case spec.Order != "":
+		return spec.Order
+	case spec.Alternate:
+		return OrderAlternate
+	default:
+		return OrderSequential
+	}
+}
+
+// MaxExecutions returns the max number of executions of any of the scenarios.
+func (spec BenchmarkSpec) MaxExecutions() int {
+	if len(spec.Scenarios) == 0 {
+		return spec.MaxExecutionsOf(ScenarioSpec{})
+	}
+
+	maxExecutions := 0
+	for _, scenario := range spec.Scenarios {
+		maxExecutions = max(maxExecutions, spec.MaxExecutionsOf(scenario))
+	}
+
+	return maxExecutions
+}
+
+// MaxExecutionsOf returns the max number of executions of the specified scenario.
+// A scenario level number of executions takes precedence over the benchmark level value and adaptive execution.
+func (spec BenchmarkSpec) MaxExecutionsOf(scenario ScenarioSpec) int {
+	switch {
+	case scenario.Executions > 0:
+		return scenario.Executions
+	case spec.Adaptive != nil:
+		return spec.Adaptive.MaxExecutions
+	default:
+		return spec.Executions
+	}
+}
+
+// HasRoundHooks returns true if this spec defines commands to execute before or after rounds of executions.
+func (spec BenchmarkSpec) HasRoundHooks() bool {
+	return spec.BeforeRound != nil || spec.AfterRound != nil
+}
+
+// HasThresholds returns true if any of the scenarios of this spec defines thresholds.
+func (spec BenchmarkSpec) HasThresholds() bool {
+	for _, scenario := range spec.Scenarios {
+		if scenario.Thresholds != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRegressionThresholds returns true if any of the scenarios of this spec defines regression thresholds.
+func (spec BenchmarkSpec) HasRegressionThresholds() bool {
+	for _, scenario := range spec.Scenarios {
+		if scenario.Thresholds != nil && scenario.Thresholds.Regression != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WarmupExecutions returns the number of warmup executions for the specified scenario.
+// A scenario level value, including zero, takes precedence over the benchmark level value.
+func (spec BenchmarkSpec) WarmupExecutions(scenario ScenarioSpec) int {
+	if scenario.Warmup != nil {
+		return *scenario.Warmup
+	}
+
+	return spec.Warmup
+}
+
+// ErrorPolicy returns the policy to apply when the specified command fails.
+// A command level policy takes precedence over the default policy of the benchmark.
+func (spec BenchmarkSpec) ErrorPolicy(cmd *CommandSpec) ErrorPolicy {
+	if cmd != nil && cmd.OnError != "" {
+		return cmd.OnError
+	}
+	if spec.FailFast {
+		return ErrorPolicyAbort
+	}
+
+	return ErrorPolicyWarn
+}
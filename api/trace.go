@@ -28,6 +28,10 @@ type Trace interface {
 	PerceivedTime() time.Duration
 	SystemCPUTime() time.Duration
 	UserCPUTime() time.Duration
+	ResourceUsage() ResourceUsage
+	Concurrency() int
+	// Wait returns the time the run waited for the load of the host to drop before it started
+	Wait() time.Duration
 	Error() error
 }
 
@@ -39,8 +43,39 @@ type Stats interface {
 	Median() (time.Duration, error)
 	Percentile(percent float64) (time.Duration, error)
 	StdDev() (time.Duration, error)
+	// MeanConfidenceInterval returns the lower and upper bounds of the mean at the specified confidence level (e.g. 0.95).
+	MeanConfidenceInterval(level float64) (time.Duration, time.Duration, error)
+	// MedianConfidenceInterval returns the lower and upper bounds of the median at the specified confidence level (e.g. 0.95).
+	MedianConfidenceInterval(level float64) (time.Duration, time.Duration, error)
+	// ErrorRate returns the rate of failed executions, including executions that timed out
 	ErrorRate() float64
+	// TimeoutRate returns the rate of executions that timed out
+	TimeoutRate() float64
+	// Outliers returns the number of samples that lie outside Tukey's fences
+	Outliers() int
+	// Count returns the total number of samples, including outliers
 	Count() int
+	// Samples returns the samples the mean, stddev and percentiles are calculated from
+	Samples() []time.Duration
+}
+
+// UsageStats provides access to resource usage statistics.
+type UsageStats interface {
+	// Mean returns the mean value of each resource usage metric
+	Mean() (ResourceUsage, error)
+	// Max returns the max value of each resource usage metric
+	Max() (ResourceUsage, error)
+}
+
+// Comparison provides access to a statistical comparison between a set of samples and a baseline set of samples.
+type Comparison interface {
+	// Ratio returns the ratio between the compared mean and the baseline mean.
+	// A value greater than 1 indicates a slowdown, a value lower than 1 indicates a speedup.
+	Ratio() (float64, error)
+	// RatioConfidenceInterval returns the lower and upper bounds of the ratio at the specified confidence level (e.g. 0.95).
+	RatioConfidenceInterval(level float64) (float64, float64, error)
+	// PValue returns the two-sided p-value of a Mann-Whitney U test between the two sets of samples.
+	PValue() (float64, error)
 }
 
 // Summary provides access a collection of identifiable statistics.
@@ -48,6 +83,10 @@ type Summary interface {
 	PerceivedTimeStats(ID) Stats
 	SystemTimeStats(ID) Stats
 	UserTimeStats(ID) Stats
+	ResourceUsageStats(ID) UsageStats
+	// PerceivedTimeComparison returns a comparison of the perceived time stats of the specified scenario against the
+	// specified baseline stats, which may come from another summary.
+	PerceivedTimeComparison(id ID, baseline Stats) Comparison
 	IDs() []ID
 	Time() time.Time
 }
@@ -37,6 +37,7 @@ func doRun(exitFn func(int)) {
 
 	// Subcommands
 	rootCmd.AddCommand(cli.CreateConfigCommand(ctx))
+	rootCmd.AddCommand(cli.CreateCompareCommand(ctx))
 	rootCmd.AddCommand(cmd.CreateShellCompletionScriptGenCommand())
 	if enableSelfUpdate() {
 		rootCmd.AddCommand(cli.CreateUpdateCommand(Version, ProgramName, ctx))
@@ -53,9 +54,13 @@ func handlePanics(exitFn func(int)) {
 			slog.Error(err.Error())
 			exitFn(1)
 		}
-		if err, ok := o.(cli.AbortionError); ok {
+		if err, ok := o.(cli.ThresholdsError); ok {
 			slog.Error(err.Error())
-			exitFn(0)
+			exitFn(cli.ExitCodeThresholdsFailed)
+		}
+		if err, ok := o.(cli.ExecutionError); ok {
+			slog.Error(err.Error())
+			exitFn(err.ExitCode())
 		}
 
 		issueURL := errorhandling.GenerateGitHubCreateNewIssueURL(
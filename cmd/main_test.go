@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/sha1n/bert/internal/cli"
 	"github.com/sha1n/termite"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,7 +47,34 @@ func TestExitCodeWithFailFastAndCommandFailure(t *testing.T) {
 		},
 	)
 
-	assert.Equal(t, 0, actualExitCode)
+	assert.Equal(t, cli.ExitCodeAborted, actualExitCode)
+}
+
+func TestExitCodeWithFailedThresholds(t *testing.T) {
+	var (
+		actualExitCode int
+		hasValue       bool
+	)
+
+	testWith(
+		t,
+		[]string{
+			"program",
+			"-c",
+			"../test/data/thresholds_failing.yaml",
+		},
+		true,
+		func(t *testing.T) {
+			doRun(func(code int) {
+				if !hasValue {
+					actualExitCode = code
+					hasValue = true
+				}
+			})
+		},
+	)
+
+	assert.Equal(t, 2, actualExitCode)
 }
 
 func TestSanity(t *testing.T) {
@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"io"
 	"os"
 	"path"
+	"time"
 
 	"github.com/sha1n/bert/api"
 	"github.com/sha1n/bert/pkg/osutil"
@@ -16,12 +18,48 @@ const (
 	ArgNameConfig = "config"
 	// ArgNameExecutions : program arg name
 	ArgNameExecutions = "executions"
+	// ArgNameWarmup : program arg name
+	ArgNameWarmup = "warmup"
 	// ArgNameAlternate : program arg name
 	ArgNameAlternate = "alternate"
+	// ArgNameOrder : program arg name
+	ArgNameOrder = "order"
+	// ArgNameSeed : program arg name
+	ArgNameSeed = "seed"
+	// ArgNameOnly : program arg name
+	ArgNameOnly = "only"
+	// ArgNameSkip : program arg name
+	ArgNameSkip = "skip"
+	// ArgNameTags : program arg name
+	ArgNameTags = "tags"
+	// ArgNameConcurrency : program arg name
+	ArgNameConcurrency = "concurrency"
+	// ArgNamePercentiles : program arg name
+	ArgNamePercentiles = "percentiles"
+	// ArgNameShell : program arg name
+	ArgNameShell = "shell"
+	// ArgNameSubtractShellOverhead : program arg name
+	ArgNameSubtractShellOverhead = "subtract-shell-overhead"
+	// ArgNameTimeout : program arg name
+	ArgNameTimeout = "timeout"
+	// ArgNameMaxLoad : program arg name
+	ArgNameMaxLoad = "max-load"
+	// ArgNameSettle : program arg name
+	ArgNameSettle = "settle"
+	// ArgNameMaxTime : program arg name
+	ArgNameMaxTime = "max-time"
+	// ArgNameVar : program arg name
+	ArgNameVar = "var"
+	// ArgNameOutputDir : program arg name
+	ArgNameOutputDir = "output-dir"
+	// ArgNameCaptureOutput : program arg name
+	ArgNameCaptureOutput = "capture-output"
 	// ArgNameFailFast : program arg name
 	ArgNameFailFast = "fail-fast"
 	// ArgNameOutputFile : program arg name
 	ArgNameOutputFile = "out-file"
+	// ArgNameSaveResults : program arg name
+	ArgNameSaveResults = "save-results"
 	// ArgNameConfigExample : program arg name
 	ArgNameConfigExample = "example"
 	// ArgNameFormat : program arg name
@@ -43,6 +81,12 @@ const (
 	ArgNameLabel = "label"
 	// ArgNameHeaders : program arg name
 	ArgNameHeaders = "headers"
+	// ArgNameBaseline : program arg name
+	ArgNameBaseline = "baseline"
+	// ArgNameRemoveOutliers : program arg name
+	ArgNameRemoveOutliers = "remove-outliers"
+	// ArgNameBaselineResults : program arg name
+	ArgNameBaselineResults = "baseline-results"
 
 	// ArgReportUTCDate : specifies that reports should report UTC time
 	ArgReportUTCDate = "utc-date"
@@ -81,6 +125,20 @@ func ResolveOutputArg(cmd *cobra.Command, name string, ctx api.IOContext) io.Wri
 	return outputFile
 }
 
+// CreateOutputFile creates or truncates the file specified by the named argument.
+// Returns nil if the argument is not specified.
+func CreateOutputFile(cmd *cobra.Command, name string) io.WriteCloser {
+	outputFilePath := GetString(cmd, name)
+	if outputFilePath == "" {
+		return nil
+	}
+
+	outputFile, err := os.Create(osutil.ExpandUserPath(outputFilePath))
+	CheckBenchmarkInitFatal(err)
+
+	return outputFile
+}
+
 // GetString tries to get a user argument. Handles errors as fatal.
 func GetString(cmd *cobra.Command, name string) string {
 	v, err := cmd.Flags().GetString(name)
@@ -97,6 +155,30 @@ func GetInt(cmd *cobra.Command, name string) int {
 	return v
 }
 
+// GetInt64 tries to get a user argument. Handles errors as fatal.
+func GetInt64(cmd *cobra.Command, name string) int64 {
+	v, err := cmd.Flags().GetInt64(name)
+	CheckUserArgFatal(err)
+
+	return v
+}
+
+// GetFloat64 tries to get a user argument. Handles errors as fatal.
+func GetFloat64(cmd *cobra.Command, name string) float64 {
+	v, err := cmd.Flags().GetFloat64(name)
+	CheckUserArgFatal(err)
+
+	return v
+}
+
+// GetDuration tries to get a user argument. Handles errors as fatal.
+func GetDuration(cmd *cobra.Command, name string) time.Duration {
+	v, err := cmd.Flags().GetDuration(name)
+	CheckUserArgFatal(err)
+
+	return v
+}
+
 // GetBool tries to get a user argument. Handles errors as fatal.
 func GetBool(cmd *cobra.Command, name string) bool {
 	var v bool
@@ -111,7 +193,23 @@ func GetBool(cmd *cobra.Command, name string) bool {
 
 // GetStringSlice tries to get a user argument. Handles errors as fatal.
 func GetStringSlice(cmd *cobra.Command, name string) []string {
-	v, err := cmd.Flags().GetStringSlice(ArgNameLabel)
+	v, err := cmd.Flags().GetStringSlice(name)
+	CheckUserArgFatal(err)
+
+	return v
+}
+
+// GetStringArray tries to get a user argument. Handles errors as fatal.
+func GetStringArray(cmd *cobra.Command, name string) []string {
+	v, err := cmd.Flags().GetStringArray(name)
+	CheckUserArgFatal(err)
+
+	return v
+}
+
+// GetFloat64Slice tries to get a user argument. Handles errors as fatal.
+func GetFloat64Slice(cmd *cobra.Command, name string) []float64 {
+	v, err := cmd.Flags().GetFloat64Slice(name)
 	CheckUserArgFatal(err)
 
 	return v
@@ -166,3 +264,16 @@ func (wc stdOutNonClosingWriteCloser) Write(b []byte) (int, error) {
 func (wc stdOutNonClosingWriteCloser) Close() error {
 	return nil
 }
+
+// multiCloser closes multiple closers
+type multiCloser []io.Closer
+
+// Close closes all closers and returns their errors
+func (c multiCloser) Close() error {
+	var errs []error
+	for _, closer := range c {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
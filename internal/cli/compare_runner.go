@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/internal/report"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/osutil"
+	"github.com/sha1n/bert/pkg/results"
+	"github.com/spf13/cobra"
+)
+
+// CreateCompareCommand creates the 'compare' sub command
+func CreateCompareCommand(ctx api.IOContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <baseline results file> <results file>...",
+		Long:  fmt.Sprintf(`Compares benchmark results saved using '--%s' against the first specified results file`, ArgNameSaveResults),
+		Short: `Compares saved benchmark results`,
+		Args:  cobra.MinimumNArgs(2),
+		Run:   runCompareFn(ctx),
+	}
+
+	cmd.Flags().StringP(ArgNameOutputFile, "o", "", `output file path. Optional. Writes to stdout by default.`)
+	cmd.Flags().StringP(ArgNameFormat, "f", ArgValueReportFormatTxt, `diff report format. One of: 'txt', 'md', 'json'`)
+	cmd.Flags().Bool(ArgNameHeaders, true, `in tabular formats, whether to include headers in the report.`)
+	cmd.Flags().Bool(ArgNameRemoveOutliers, false, `whether to exclude outliers from the mean, stddev and percentiles. outliers are samples outside Tukey's fences.`)
+
+	_ = cmd.MarkFlagFilename(ArgNameOutputFile, "txt", "md", "json")
+
+	return cmd
+}
+
+// runCompareFn returns a function that loads the specified results files and writes a diff report with the specified IOContext
+func runCompareFn(ctx api.IOContext) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		configureOutput(cmd, slog.LevelError, ctx)
+
+		runs, err := loadComparedRuns(args, exec.SummaryOptions{RemoveOutliers: GetBool(cmd, ArgNameRemoveOutliers)})
+		CheckBenchmarkInitFatal(err)
+
+		writeCloser := ResolveOutputArg(cmd, ArgNameOutputFile, ctx)
+		defer func() {
+			_ = writeCloser.Close()
+		}()
+
+		var writeReportFn report.WriteDiffReportFn
+		switch reportFormat := GetString(cmd, ArgNameFormat); reportFormat {
+		case ArgValueReportFormatTxt:
+			writeReportFn = report.NewTextDiffReportWriter(writeCloser)
+		case ArgValueReportFormatMarkdown:
+			writeReportFn = report.NewMarkdownDiffReportWriter(writeCloser)
+		case ArgValueReportFormatJSON:
+			writeReportFn = report.NewJSONDiffReportWriter(writeCloser)
+		default:
+			err = fmt.Errorf("invalid diff report format '%s'", reportFormat)
+		}
+		CheckUserArgFatal(err)
+
+		CheckFatal(writeReportFn(runs, api.ReportContext{IncludeHeaders: GetBool(cmd, ArgNameHeaders)}))
+	}
+}
+
+func loadComparedRuns(paths []string, opts exec.SummaryOptions) (runs []report.ComparedRun, err error) {
+	names := comparedRunNames(paths)
+	for i, path := range paths {
+		var r results.Results
+		if r, err = results.Load(osutil.ExpandUserPath(path)); err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, report.ComparedRun{
+			Name:    names[i],
+			Summary: exec.NewSummaryWith(r.TracesByID(), opts),
+		})
+	}
+
+	return runs, nil
+}
+
+// comparedRunNames returns a unique name for each of the specified results files. Runs are named after the base names
+// of their files, unless they are shared by other files, in which case the paths are used as specified. Runs of
+// files that are specified more than once are numbered by their position.
+func comparedRunNames(paths []string) []string {
+	baseNameCount := map[string]int{}
+	for _, path := range paths {
+		baseNameCount[filepath.Base(path)]++
+	}
+
+	names := make([]string, len(paths))
+	nameCount := map[string]int{}
+	for i, path := range paths {
+		names[i] = filepath.Base(path)
+		if baseNameCount[names[i]] > 1 {
+			names[i] = path
+		}
+		nameCount[names[i]]++
+	}
+	for i, name := range names {
+		if nameCount[name] > 1 {
+			names[i] = fmt.Sprintf("%s #%d", name, i+1)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	gommonstest "github.com/sha1n/gommons/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareSavedResults(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	otherPath := filepath.Join(t.TempDir(), "other.json")
+
+	for _, path := range []string{baselinePath, otherPath} {
+		runBenchmarkCommandWithPipedStdoutputsAnd(
+			t,
+			func(stdout, stderr string, err error) {
+				assert.NoError(t, err)
+			},
+			itConfigFileArgValue, "--save-results", path,
+		)
+	}
+
+	for _, format := range []string{ArgValueReportFormatTxt, ArgValueReportFormatMarkdown, ArgValueReportFormatJSON} {
+		stdout := runCompareCommand(t, baselinePath, otherPath, "--format", format)
+
+		assert.Contains(t, stdout, "NAME")
+		assert.Contains(t, stdout, "baseline.json")
+		assert.Contains(t, stdout, "other.json")
+	}
+}
+
+func TestCompareSavedResultsWithTheSameFileName(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "main", "results.json")
+	otherPath := filepath.Join(dir, "feature", "results.json")
+
+	for _, path := range []string{baselinePath, otherPath} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		runBenchmarkCommandWithPipedStdoutputsAnd(
+			t,
+			func(stdout, stderr string, err error) {
+				assert.NoError(t, err)
+			},
+			itConfigFileArgValue, "--executions=1", "--save-results", path,
+		)
+	}
+
+	stdout := runCompareCommand(t, baselinePath, otherPath, "--format", ArgValueReportFormatMarkdown)
+
+	assert.Contains(t, stdout, baselinePath)
+	assert.Contains(t, stdout, otherPath)
+}
+
+func Test_comparedRunNames(t *testing.T) {
+	assert.Equal(t, []string{"a.json", "b.json"}, comparedRunNames([]string{"x/a.json", "y/b.json"}))
+	assert.Equal(t, []string{"main/results.json", "feature/results.json", "other.json"},
+		comparedRunNames([]string{"main/results.json", "feature/results.json", "x/other.json"}))
+	assert.Equal(t, []string{"a.json #1", "a.json #2"}, comparedRunNames([]string{"a.json", "a.json"}))
+}
+
+func TestCompareWithMissingResultsFile(t *testing.T) {
+	assert.Panics(t, func() {
+		runCompareCommand(t, filepath.Join(t.TempDir(), "a.json"), filepath.Join(t.TempDir(), "b.json"))
+	})
+}
+
+func runCompareCommand(t *testing.T, args ...string) string {
+	outBuf := new(bytes.Buffer)
+	ioContext := api.NewIOContext()
+	ioContext.StdoutWriter = outBuf
+	ioContext.StderrWriter = new(bytes.Buffer)
+
+	rootCmd := NewRootCommand(gommonstest.RandomString(), gommonstest.RandomString(), gommonstest.RandomString(), ioContext)
+	rootCmd.AddCommand(CreateCompareCommand(ioContext))
+	rootCmd.SetArgs(append([]string{"compare"}, args...))
+	rootCmd.SetOut(outBuf)
+
+	assert.NoError(t, rootCmd.Execute())
+
+	return outBuf.String()
+}
@@ -148,13 +148,50 @@ more here: https://github.com/sha1n/bert/blob/master/docs/configuration.md
 
 func getExampleSpec() string {
 	return `alternate: true           # 'true' to alternate scenario executions. More details below. (default=false)
-executions: 100           # required. number of times to execute each scenario
+order: alternate          # one of 'sequential', 'alternate', 'random' and 'shuffle'. More details below. (default=sequential)
+seed: 42                  # the seed of 'random' and 'shuffle' orders. (default=a random seed, included in reports)
+executions: 100           # number of times to execute each scenario. required unless 'adaptive' is set, or every scenario sets its own
+warmup: 2                 # number of warmup executions per scenario. warmup executions are not included in the stats (default=0)
+percentiles: [50, 90, 99] # percentiles to include in summary reports (default=[90])
+timeout: 5m               # default timeout of commands that don't specify one. commands that exceed it are killed (default=none)
+captureOutput: ~/captured # saves the stdout and stderr of each measured run as '<scenario>/run-<index>.out' and '.err' (default=none)
+maxLoad: 2.0              # measured runs wait for the 1 minute load average of the host to drop below this value. More details below. (default=none)
+settle: 30s               # the maximum time measured runs wait for the load to drop below 'maxLoad' (default=1m)
+maxTime: 2h               # no new runs are started once the benchmark has run this long. More details below. (default=none)
+vars:                     # variables that can be referenced as '${name}' in commands, env and working directories. More details below.
+  target: release
+defaults:                 # values inherited by every scenario that doesn't set its own. 'workingDir', 'env', 'isolation' and hooks are supported
+  env:
+    LC_ALL: C
+setup:                    # command to be executed once before any scenario starts. More details below.
+  onError: abort
+  cmd:
+  - make
+teardown:                 # command to be executed once after all scenarios are done, even if the benchmark is aborted
+  cmd:
+  - make
+  - clean
+beforeRound:              # command to be executed before each round of 'alternate' and 'random' orders. also 'afterRound'
+  cmd:
+  - sync
 scenarios:                # list of scenarios
 - name: full scenario     # required. unique scenario name 
   workingDir: "/dir"      # default working directory for commands executed in the context of this scenario 
   env:                    # environment variables to be set for commands executed in the context of this scenario 
     NAME: value
+  warmup: 5               # overrides the benchmark level warmup value for this scenario
+  executions: 20          # overrides the benchmark level number of executions and adaptive execution for this scenario
+  maxDuration: 10m        # no new runs of this scenario are started once its runs have taken this long (default=none)
+  maxErrors: 5            # skips the remaining executions of this scenario once more errors are reported (default=none)
+  tags: [build, fast]     # tags that select this scenario with '--tags'. inherited by scenarios that extend it
+  isolation:              # Linux only. applied to the benchmarked command. More details below.
+    cpus: [2, 3]          # the CPUs the command may run on
+    nice: 5               # scheduling priority, from -20 (highest) to 19 (lowest)
+    ioNice: 4             # best-effort I/O priority, from 0 (highest) to 7 (lowest)
+    rlimits:              # resource limits: 'as', 'core', 'cpu', 'data', 'fsize', 'memlock', 'nofile', 'nproc' and 'stack'
+      nofile: 1024
   beforeAll:              # command to be executed once before any other command is executed in the context of this scenario
+    retries: 2            # number of times to retry a failed hook before its failure is handled (default=0)
     cmd:                  # required. command line arguments.
     - command
     - --flag
@@ -168,17 +205,43 @@ scenarios:                # list of scenarios
     - command
     - --flag
   afterEach:              # command to be executed after each execution of this scenario
+    onError: ignore       # one of 'ignore', 'warn', 'skip-scenario' and 'abort'. (default=warn)
     cmd:                  # required. command line arguments.
     - command
     - --flag
   command:                # required. the benchmarked command of this scenario - the one stats are collected for
+    shell: false          # 'true' to run the command with 'sh -c', or the name of a shell to run it with. (default=false)
+    timeout: 30s          # overrides the benchmark level timeout for this command
+    stdin: input.json     # a file to feed to the standard input of each run, relative to the working directory of the command
+    expect:               # assertions on the outcome of each run. a run that fails any of them is recorded as an error
+      exitCode: [0, 1]    # accepted exit codes (default=[0])
+      stderrNotMatches: "(?i)panic"   # also 'stdoutMatches', 'stdoutNotMatches' and 'stderrMatches'
     cmd:                  # required. command line arguments.
     - benchmarked-command
     - --flag
     - --arg=value
+  thresholds:             # assertions on the stats of this scenario. bert exits with code 2 if any of them fails
+    mean: 2s              # max mean duration. 'median', 'p90' and 'max' are also supported
+    errorRate: 0          # max error rate in percents
+    regression:           # max regression in percents, relative to the results specified with '--baseline-results'
+      mean: 5             # 'median' and 'p90' are also supported
+- name: sweep ${threads}    # '${param}' references are replaced with parameter values
+  parameters:             # expands the scenario into one scenario per combination of parameter values
+    threads: [1, 2, 4]
+  command:
+    cmd:
+    - command
+    - --threads=${threads}
 - name: minimal scenario
   command:
     cmd:
     - command
+- name: derived scenario
+  extends: full scenario  # inherits every value it doesn't set from the extended scenario
+  command:
+    cmd:
+    - benchmarked-command
+    - --other-flag
+    - --target=${target}
 `
 }
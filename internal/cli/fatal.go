@@ -1,7 +1,22 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/thresholds"
+)
+
+const (
+	// ExitCodeThresholdsFailed the exit code of a benchmark in which one or more threshold checks failed
+	ExitCodeThresholdsFailed = 2
+	// ExitCodeExecutionErrors the exit code of a benchmark in which one or more commands failed
+	ExitCodeExecutionErrors = 3
+	// ExitCodeScenariosSkipped the exit code of a benchmark in which one or more scenarios were skipped due to command failures
+	ExitCodeScenariosSkipped = 4
+	// ExitCodeAborted the exit code of a benchmark that was aborted due to a command failure
+	ExitCodeAborted = 5
 )
 
 // FatalUserError a marker type for fatal user errors.
@@ -38,8 +53,59 @@ func CheckUserArgFatal(err error) {
 	}
 }
 
-// CheckFatal checks the specified error and treats it as fatal if not nil
+// ThresholdsError a marker type for failed threshold checks.
+// This type of errors is reported with a dedicated exit code, so it can be used to gate CI pipelines.
+type ThresholdsError struct {
+	message string
+}
+
+func (e ThresholdsError) Error() string {
+	return e.message
+}
+
+// ExecutionError a marker type for benchmarks in which commands failed.
+// This type of errors is reported with a dedicated exit code per outcome, see ExitCode.
+type ExecutionError struct {
+	message  string
+	exitCode int
+}
+
+func (e ExecutionError) Error() string {
+	return e.message
+}
+
+// ExitCode returns the exit code that corresponds to the outcome of the benchmark.
+func (e ExecutionError) ExitCode() int {
+	return e.exitCode
+}
+
+func newExecutionError(message string, err *exec.ExecutionError) ExecutionError {
+	exitCode := ExitCodeExecutionErrors
+	if err.Aborted {
+		exitCode = ExitCodeAborted
+	} else if len(err.Skipped) > 0 {
+		exitCode = ExitCodeScenariosSkipped
+	}
+
+	return ExecutionError{message: message, exitCode: exitCode}
+}
+
+// CheckFatal checks the specified error and treats it as fatal if not nil.
+// An aborted benchmark or skipped scenarios are reported as an ExecutionError, then failed threshold checks
+// are reported as a ThresholdsError, then any other command failures are reported as an ExecutionError.
 func CheckFatal(err error) {
+	var executionErr *exec.ExecutionError
+	var thresholdsErr *thresholds.FailedError
+	hasExecutionErr := errors.As(err, &executionErr)
+	if hasExecutionErr && (executionErr.Aborted || len(executionErr.Skipped) > 0) {
+		panic(newExecutionError(err.Error(), executionErr))
+	}
+	if errors.As(err, &thresholdsErr) {
+		panic(ThresholdsError{message: err.Error()})
+	}
+	if hasExecutionErr {
+		panic(newExecutionError(err.Error(), executionErr))
+	}
 	if err != nil {
 		panic(NewFatalUserErrorf("Error: %s", err.Error()))
 	}
@@ -2,15 +2,20 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"math/rand/v2"
 	"os"
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/sha1n/bert/api"
 	"github.com/sha1n/bert/internal/report"
@@ -18,10 +23,12 @@ import (
 	"github.com/sha1n/bert/pkg/osutil"
 
 	"github.com/sha1n/bert/pkg/reporthandlers"
+	"github.com/sha1n/bert/pkg/results"
 	"github.com/sha1n/bert/pkg/specs"
 	"github.com/sha1n/bert/pkg/ui"
 	"github.com/sha1n/termite"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const bert = `
@@ -57,15 +64,47 @@ Build label: %s`, version, build),
 
 		SilenceUsage: false,
 		Args:         validatePositionalArgs,
-		Run:          runFn(ctx),
+		Run:          runFn(version, build, ctx),
 	}
 
 	rootCmd.Flags().StringP(ArgNameConfig, "c", "", `config file path. '~' will be expanded.`)
 	rootCmd.Flags().IntP(ArgNameExecutions, "e", 0, `the number of executions per scenario.
 required when no configuration file is provided. 
-when specified with a configuration file, this argument has priority.`)
+when specified with a configuration file, this argument overrides the benchmark level value and disables adaptive execution.
+scenarios that specify their own number of executions are not affected.`)
+	rootCmd.Flags().IntP(ArgNameWarmup, "w", 0, `the number of warmup executions per scenario. warmup executions are not included in the stats.
+when specified with a configuration file, this argument overrides the benchmark and scenario level values. '0' disables warmup executions.`)
 	rootCmd.Flags().BoolP(ArgNameAlternate, "a", false, `whether to use alternate executions or finish one scenario before commencing to the next one.`)
-	rootCmd.Flags().BoolP(ArgNameFailFast, "k", false, `whether to exit immediately on the first execution failure and print the process output.`)
+	rootCmd.Flags().String(ArgNameOrder, "", `the order of scenario executions. One of: 'sequential', 'alternate', 'random', 'shuffle'
+random  - executions run in rounds of one execution per scenario, each round in a random order.
+shuffle - all the executions of all the scenarios run in a random order.
+when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().Int64(ArgNameSeed, 0, `the seed of random execution orders. a random seed is used by default and is included in the report, so runs can be reproduced.`)
+	rootCmd.Flags().StringArray(ArgNameOnly, []string{}, `a scenario to execute. either a scenario name, a glob, e.g. 'build*',
+or a regular expression wrapped in slashes, e.g. '/^build (debug|release)$/'. may be specified more than once.`)
+	rootCmd.Flags().StringArray(ArgNameSkip, []string{}, `a scenario not to execute, in the same format as '--only'. may be specified more than once.`)
+	rootCmd.Flags().StringSlice(ArgNameTags, []string{}, `a comma separated list of tags. only scenarios that have any of them are executed.`)
+	rootCmd.Flags().IntP(ArgNameConcurrency, "j", 0, `the maximum number of benchmarked commands to run concurrently. executions run one at a time by default.
+when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().String(ArgNameShell, "", `a shell to run inline commands with, using '<shell> -c <command>', so pipes, redirects, globs and '&&' work.
+'--shell' without a value uses 'sh'. inline commands are split into arguments by bert by default.`)
+	rootCmd.Flags().Lookup(ArgNameShell).NoOptDefVal = string(api.DefaultShell)
+	rootCmd.Flags().Bool(ArgNameSubtractShellOverhead, false, `whether to measure the startup time of shells and subtract it from the measurements of commands that run in a shell.`)
+	rootCmd.Flags().Duration(ArgNameTimeout, 0, `the maximum duration of a single command run, e.g. '30s'. commands that exceed it are killed along with their child processes.
+when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().Float64(ArgNameMaxLoad, 0, `a 1 minute load average of the host to wait for before each measured run, e.g. '1.5'. runs start once the load drops below it,
+or once the '--settle' time has passed. when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().Duration(ArgNameSettle, 0, `the maximum time to wait for the load of the host to drop below '--max-load' before each measured run, e.g. '30s'. (default 1m)
+when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().Duration(ArgNameMaxTime, 0, `the maximum total duration of the benchmark, e.g. '10m'. once it is spent, no new runs are started and whatever has been
+collected is reported. when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().StringArray(ArgNameVar, []string{}, `a 'name=value' variable that can be referenced as '${name}' in commands, environment variables and working directories.
+can be specified multiple times. when specified with a configuration file, overrides the variable of the same name in the file.`)
+	rootCmd.Flags().String(ArgNameCaptureOutput, "", `a directory to save the standard output and error of each measured run to, as '<scenario>/run-<index>.out' and '.err'.
+created if it doesn't exist. '~' will be expanded. when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().String(ArgNameOutputDir, "", `a directory for commands to write files to, passed to them in the 'BERT_OUTPUT_DIR' variable. created if it doesn't exist.
+'~' will be expanded. when specified with a configuration file, this argument overrides the benchmark level value. (default: current directory)`)
+	rootCmd.Flags().BoolP(ArgNameFailFast, "k", false, `whether to abort the benchmark on the first execution failure. sets the default error policy of commands to 'abort'.`)
 
 	// Reporting
 	rootCmd.Flags().StringP(ArgNameOutputFile, "o", "", `output file path. Optional. Writes to stdout by default.`)
@@ -77,9 +116,19 @@ csv/raw - CSV document in which each row represents a raw trace event. useful if
 md      - markdown table. similar to CSV but writes in markdown table format.
 md/raw  - markdown table in which each row represents a raw trace event.`,
 	)
+	rootCmd.Flags().String(ArgNameSaveResults, "", `a file path to save the raw results of this benchmark to, along with the spec, labels and host information.
+saved results can be compared using the 'compare' command. '~' will be expanded.`)
 	rootCmd.Flags().StringSliceP(ArgNameLabel, "l", []string{}, `labels to attach to be included in the benchmark report.`)
 	rootCmd.Flags().Bool(ArgNameHeaders, true, `in tabular formats, whether to include headers in the report.`)
 	rootCmd.Flags().Bool(ArgReportUTCDate, false, `whether to use UTC date.`)
+	rootCmd.Flags().String(ArgNameBaseline, "", `the name of a scenario to compare all other scenarios against.
+when specified, 'txt', 'md' and 'json' summaries include a comparison section with the relative speedup or slowdown
+of each scenario, a confidence interval and the p-value of a Mann-Whitney U test.`)
+	rootCmd.Flags().Float64Slice(ArgNamePercentiles, []float64{}, `a comma separated list of percentiles to include in summary reports, e.g. '50,95,99.9'. (default 90)
+when specified with a configuration file, this argument overrides the benchmark level value.`)
+	rootCmd.Flags().Bool(ArgNameRemoveOutliers, false, `whether to exclude outliers from the mean, stddev and percentiles. outliers are samples outside Tukey's fences.`)
+	rootCmd.Flags().String(ArgNameBaselineResults, "", `a results file saved with '--save-results' to evaluate the regression thresholds of the spec against.
+required when the spec defines regression thresholds. '~' will be expanded.`)
 
 	// Stdout
 	rootCmd.Flags().Bool(ArgNamePipeStdout, false, `pipes external commands standard out to bert's standard out.`)
@@ -92,6 +141,8 @@ md/raw  - markdown table in which each row represents a raw trace event.`,
 
 	_ = rootCmd.MarkFlagFilename(ArgNameConfig, "yml", "yaml", "json")
 	_ = rootCmd.MarkFlagFilename(ArgNameOutputFile, "txt", "csv", "md", "json")
+	_ = rootCmd.MarkFlagFilename(ArgNameSaveResults, "json")
+	_ = rootCmd.MarkFlagFilename(ArgNameBaselineResults, "json")
 
 	rootCmd.SetVersionTemplate(`{{printf "%s" .Version}}`)
 	rootCmd.SetUsageTemplate(rootCmd.UsageTemplate() + bert)
@@ -100,7 +151,7 @@ md/raw  - markdown table in which each row represents a raw trace event.`,
 }
 
 // runFn returns a function that parses CLI arguments and runs the benchmark process with the specified IOContext
-func runFn(ctx api.IOContext) func(*cobra.Command, []string) {
+func runFn(version, build string, ctx api.IOContext) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		var err error
 		var closer io.Closer
@@ -112,14 +163,16 @@ func runFn(ctx api.IOContext) func(*cobra.Command, []string) {
 		spec, err = loadSpec(cmd, args)
 		CheckBenchmarkInitFatal(err)
 
+		metadata := newRunMetadata(cmd, version, build)
+
 		var reportHandler api.ReportHandler
-		reportHandler, closer, err = resolveReportHandler(cmd, spec, ctx)
+		reportHandler, closer, err = resolveReportHandler(cmd, spec, metadata, ctx)
 		defer func() {
 			_ = closer.Close()
 		}()
 
 		if err == nil {
-			tracer := exec.NewTracer(spec.Executions * len(spec.Scenarios))
+			tracer := exec.NewTracer(spec.MaxExecutions() * len(spec.Scenarios))
 			reportHandler.Subscribe(tracer.Stream())
 
 			slog.Info("Executing...")
@@ -128,10 +181,15 @@ func runFn(ctx api.IOContext) func(*cobra.Command, []string) {
 			execCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 			defer cancel()
 
-			exec.Execute(execCtx, spec, resolveExecutionContext(cmd, spec, ctx, tracer))
+			metadata.Host = osutil.CurrentHost()
+			metadata.StartTime = time.Now()
+			benchmarkCtx := resolveExecutionContext(cmd, spec, ctx, tracer)
+			benchmarkCtx.Metadata = metadata
+			execErr := exec.Execute(execCtx, spec, benchmarkCtx)
+			metadata.End(time.Now())
 
 			slog.Info("Finalizing report...")
-			err = reportHandler.Finalize()
+			err = errors.Join(execErr, reportHandler.Finalize())
 
 			slog.Info("Done")
 		}
@@ -143,15 +201,36 @@ func runFn(ctx api.IOContext) func(*cobra.Command, []string) {
 
 func loadSpec(cmd *cobra.Command, args []string) (spec api.BenchmarkSpec, err error) {
 	executions := GetInt(cmd, ArgNameExecutions)
+	warmup := GetInt(cmd, ArgNameWarmup)
 	alternate := GetBool(cmd, ArgNameAlternate)
+	concurrency := GetInt(cmd, ArgNameConcurrency)
 	failFast := GetBool(cmd, ArgNameFailFast)
+	percentiles := GetFloat64Slice(cmd, ArgNamePercentiles)
+	shell := GetString(cmd, ArgNameShell)
+	subtractShellOverhead := GetBool(cmd, ArgNameSubtractShellOverhead)
+	timeout := GetDuration(cmd, ArgNameTimeout)
+	order := api.ExecutionOrder(GetString(cmd, ArgNameOrder))
+	maxLoad := GetFloat64(cmd, ArgNameMaxLoad)
+	settle := GetDuration(cmd, ArgNameSettle)
+	maxTime := GetDuration(cmd, ArgNameMaxTime)
+	vars := GetStringArray(cmd, ArgNameVar)
+	outputDir := GetString(cmd, ArgNameOutputDir)
+	captureOutput := GetString(cmd, ArgNameCaptureOutput)
+	seed := GetInt64(cmd, ArgNameSeed)
 
 	if len(args) > 0 { // positional args are used for ad-hoc config
 		commands := []api.CommandSpec{}
 		for i := range args {
-			commands = append(commands, api.CommandSpec{
-				Cmd: parseCommand(strings.Trim(args[i], "'\"")),
-			})
+			if shell != "" {
+				commands = append(commands, api.CommandSpec{
+					Cmd:   []string{args[i]},
+					Shell: api.Shell(shell),
+				})
+			} else {
+				commands = append(commands, api.CommandSpec{
+					Cmd: parseCommand(strings.Trim(args[i], "'\"")),
+				})
+			}
 		}
 		spec, err = specs.CreateSpecFrom(executions, alternate, failFast, commands...)
 
@@ -175,19 +254,147 @@ func loadSpec(cmd *cobra.Command, args []string) (spec api.BenchmarkSpec, err er
 		}
 	}
 
-	// Override executions if specified
+	// Filter scenarios if a filter is specified
+	if spec, err = specs.FilterScenarios(spec, resolveScenarioFilter(cmd)); err != nil {
+		return
+	}
+
+	// Override executions if specified. A fixed number of executions replaces adaptive execution,
+	// but not the number of executions of scenarios that specify their own.
 	if executions > 0 {
 		spec.Executions = executions
+		spec.Adaptive = nil
+	}
+
+	// Override warmup if specified, including an explicit zero, which disables warmup executions.
+	// The specified value applies to all the scenarios, including those that specify their own.
+	if cmd.Flags().Changed(ArgNameWarmup) {
+		if warmup < 0 {
+			err = fmt.Errorf("invalid warmup '%d', warmup must not be negative", warmup)
+			return
+		}
+		spec.Warmup = warmup
+		for i := range spec.Scenarios {
+			spec.Scenarios[i].Warmup = nil
+		}
+	}
+
+	// Override concurrency if specified
+	if concurrency > 0 {
+		spec.Concurrency = concurrency
+	}
+
+	// Override percentiles if specified
+	if len(percentiles) > 0 {
+		if err = validatePercentiles(percentiles); err != nil {
+			return
+		}
+		spec.Percentiles = percentiles
+	}
+
+	// Override the default command timeout if specified
+	if timeout < 0 {
+		err = fmt.Errorf("invalid timeout '%s', timeout must not be negative", timeout)
+		return
+	}
+	if timeout > 0 {
+		spec.Timeout = api.Duration(timeout)
+	}
+
+	// Override the load gating of measured runs if specified
+	if maxLoad < 0 {
+		err = fmt.Errorf("invalid max load '%v', max load must not be negative", maxLoad)
+		return
+	}
+	if maxLoad > 0 {
+		spec.MaxLoad = maxLoad
+	}
+	if settle < 0 {
+		err = fmt.Errorf("invalid settle time '%s', settle time must not be negative", settle)
+		return
+	}
+	if settle > 0 {
+		spec.Settle = api.Duration(settle)
+	}
+
+	// Override the time budget of the benchmark if specified
+	if maxTime < 0 {
+		err = fmt.Errorf("invalid max time '%s', max time must not be negative", maxTime)
+		return
+	}
+	if maxTime > 0 {
+		spec.MaxTime = api.Duration(maxTime)
+	}
+
+	// Override variables if specified
+	for _, v := range vars {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			err = fmt.Errorf("invalid variable '%s', variables must be specified as 'name=value'", v)
+			return
+		}
+		if spec.Vars == nil {
+			spec.Vars = map[string]string{}
+		}
+		spec.Vars[name] = value
+	}
+
+	// Override the output directory if specified
+	if outputDir != "" {
+		spec.OutputDir = outputDir
+	}
+	if spec.OutputDir != "" {
+		if spec.OutputDir, err = createDir(spec.OutputDir, "output"); err != nil {
+			return
+		}
+	}
+
+	// Override the output capture directory if specified
+	if captureOutput != "" {
+		spec.CaptureOutput = captureOutput
+	}
+	if spec.CaptureOutput != "" {
+		if spec.CaptureOutput, err = createDir(spec.CaptureOutput, "output capture"); err != nil {
+			return
+		}
+	}
+
+	// Override the execution order if specified. '--alternate' is a shorthand for '--order alternate'
+	if order != "" {
+		if err = validateOrder(order, alternate); err != nil {
+			return
+		}
+		spec.Order = order
+		spec.Alternate = order == api.OrderAlternate
+	} else if alternate {
+		spec.Order = ""
+		spec.Alternate = true
 	}
 
-	spec.Alternate = alternate || spec.Alternate
+	// Override the seed if specified. Random orders always use a seed, so that reported runs can be reproduced
+	if seed != 0 {
+		spec.Seed = seed
+	}
+	if spec.ExecutionOrder().IsRandom() && spec.Seed == 0 {
+		spec.Seed = rand.Int64()
+	}
+
+	spec.SubtractShellOverhead = subtractShellOverhead || spec.SubtractShellOverhead
 
 	return spec, err
 }
 
-func resolveReportHandler(cmd *cobra.Command, spec api.BenchmarkSpec, ctx api.IOContext) (handler api.ReportHandler, closer io.Closer, err error) {
-	reportCtx := resolveReportContext(cmd)
+func resolveReportHandler(cmd *cobra.Command, spec api.BenchmarkSpec, metadata *api.RunMetadata, ctx api.IOContext) (handler api.ReportHandler, closer io.Closer, err error) {
 	writeCloser := ResolveOutputArg(cmd, ArgNameOutputFile, ctx)
+
+	var reportCtx api.ReportContext
+	if reportCtx, err = resolveReportContext(cmd, spec); err != nil {
+		return handler, writeCloser, err
+	}
+	reportCtx.Metadata = metadata
+	if err = validateBaseline(reportCtx.Baseline, spec); err != nil {
+		return handler, writeCloser, err
+	}
 	writer := writeCloser
 
 	switch reportFormat := GetString(cmd, ArgNameFormat); reportFormat {
@@ -220,17 +427,158 @@ func resolveReportHandler(cmd *cobra.Command, spec api.BenchmarkSpec, ctx api.IO
 		err = fmt.Errorf("invalid report format '%s'", reportFormat)
 	}
 
-	return handler, writeCloser, err
+	if err != nil {
+		return handler, writeCloser, err
+	}
+
+	handlers := []api.ReportHandler{handler}
+	closers := multiCloser{writeCloser}
+
+	if spec.HasThresholds() {
+		handlers = append(handlers, reporthandlers.NewThresholdsReportHandler(spec, reportCtx))
+	}
+	if resultsWriteCloser := CreateOutputFile(cmd, ArgNameSaveResults); resultsWriteCloser != nil {
+		handlers = append(handlers, reporthandlers.NewResultsReportHandler(withRedactedVars(cmd, spec), reportCtx, resultsWriteCloser))
+		closers = append(closers, resultsWriteCloser)
+	}
+
+	if len(handlers) > 1 {
+		handler = reporthandlers.NewCompositeReportHandler(handlers...)
+	}
+
+	return handler, closers, err
 }
 
-func resolveReportContext(cmd *cobra.Command) api.ReportContext {
-	return api.ReportContext{
+func resolveReportContext(cmd *cobra.Command, spec api.BenchmarkSpec) (reportCtx api.ReportContext, err error) {
+	reportCtx = api.ReportContext{
 		Labels:         GetStringSlice(cmd, ArgNameLabel),
 		IncludeHeaders: GetBool(cmd, ArgNameHeaders),
 		UTCDate:        GetBool(cmd, ArgReportUTCDate),
+		Baseline:       GetString(cmd, ArgNameBaseline),
+		RemoveOutliers: GetBool(cmd, ArgNameRemoveOutliers),
+		Filter:         resolveScenarioFilter(cmd),
+	}
+
+	baselineResultsPath := GetString(cmd, ArgNameBaselineResults)
+	if baselineResultsPath == "" {
+		if spec.HasRegressionThresholds() {
+			err = fmt.Errorf("the benchmark spec defines regression thresholds, but no '--%s' file was specified", ArgNameBaselineResults)
+		}
+		return reportCtx, err
+	}
+
+	var baselineResults results.Results
+	if baselineResults, err = results.Load(osutil.ExpandUserPath(baselineResultsPath)); err == nil {
+		reportCtx.BaselineSummary = exec.NewSummaryWith(baselineResults.TracesByID(), exec.SummaryOptions{RemoveOutliers: reportCtx.RemoveOutliers})
+	}
+
+	return reportCtx, err
+}
+
+// createDir creates the specified directory if it doesn't exist and returns its absolute path. '~' is expanded.
+func createDir(dir string, description string) (absDir string, err error) {
+	if absDir, err = filepath.Abs(osutil.ExpandUserPath(dir)); err != nil {
+		return
+	}
+	if err = os.MkdirAll(absDir, 0755); err != nil {
+		err = fmt.Errorf("failed to create the %s directory '%s'. %w", description, absDir, err)
+	}
+
+	return
+}
+
+// redactedValue replaces the values of variables that are specified on the command line in reports and saved results
+const redactedValue = "<redacted>"
+
+// newRunMetadata returns the metadata of a run of the specified version of bert, with the flags that were specified
+// on the command line. The host and timing information is recorded when the run is executed.
+func newRunMetadata(cmd *cobra.Command, version, build string) *api.RunMetadata {
+	flags := map[string][]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			flags[f.Name] = slices.Clone(slice.GetSlice())
+		} else {
+			flags[f.Name] = []string{f.Value.String()}
+		}
+	})
+	// variables may hold secrets, such as tokens, so only their names are recorded
+	for i, variable := range flags[ArgNameVar] {
+		name, _, _ := strings.Cut(variable, "=")
+		flags[ArgNameVar][i] = name + "=" + redactedValue
+	}
+
+	return &api.RunMetadata{
+		Version: version,
+		Build:   build,
+		Flags:   flags,
 	}
 }
 
+// withRedactedVars returns a copy of the specified spec in which the values of the variables that were specified on
+// the command line are redacted, because they may hold secrets, such as tokens.
+func withRedactedVars(cmd *cobra.Command, spec api.BenchmarkSpec) api.BenchmarkSpec {
+	vars := GetStringArray(cmd, ArgNameVar)
+	if len(vars) == 0 {
+		return spec
+	}
+
+	spec.Vars = maps.Clone(spec.Vars)
+	for _, variable := range vars {
+		name, _, _ := strings.Cut(variable, "=")
+		if _, ok := spec.Vars[name]; ok {
+			spec.Vars[name] = redactedValue
+		}
+	}
+
+	return spec
+}
+
+func resolveScenarioFilter(cmd *cobra.Command) api.ScenarioFilter {
+	return api.ScenarioFilter{
+		Only: GetStringArray(cmd, ArgNameOnly),
+		Skip: GetStringArray(cmd, ArgNameSkip),
+		Tags: GetStringSlice(cmd, ArgNameTags),
+	}
+}
+
+func validateBaseline(baseline api.ID, spec api.BenchmarkSpec) error {
+	if baseline == "" {
+		return nil
+	}
+
+	for _, scenario := range spec.Scenarios {
+		if scenario.ID() == baseline {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the baseline scenario '%s' is not defined in the benchmark spec", baseline)
+}
+
+func validatePercentiles(percentiles []float64) error {
+	for _, p := range percentiles {
+		if p <= 0 || p > 100 {
+			return fmt.Errorf("invalid percentile '%v', percentiles must be greater than 0 and at most 100", p)
+		}
+	}
+
+	return nil
+}
+
+func validateOrder(order api.ExecutionOrder, alternate bool) error {
+	switch order {
+	case api.OrderSequential, api.OrderRandom, api.OrderShuffle:
+		if alternate {
+			return fmt.Errorf("'--%s' can't be combined with '--%s %s'", ArgNameAlternate, ArgNameOrder, order)
+		}
+	case api.OrderAlternate:
+	default:
+		return fmt.Errorf("invalid order '%s', order must be one of 'sequential', 'alternate', 'random' and 'shuffle'", order)
+	}
+
+	return nil
+}
+
 func resolveExecutionContext(cmd *cobra.Command, spec api.BenchmarkSpec, ctx api.IOContext, tracer api.Tracer) api.ExecutionContext {
 	pipeStdOut := GetBool(cmd, ArgNamePipeStdout)
 	pipeStdErr := GetBool(cmd, ArgNamePipeStderr)
@@ -243,18 +591,11 @@ func resolveExecutionContext(cmd *cobra.Command, spec api.BenchmarkSpec, ctx api
 }
 
 func resolveExecutionListener(cmd *cobra.Command, spec api.BenchmarkSpec, ctx api.IOContext) api.Listener {
-	var listener api.Listener
 	if enableTerminalGUI(cmd, ctx) {
-		listener = ui.NewProgressView(spec, terminalDimensionsOrFake, ctx)
-	} else {
-		listener = ui.NewLoggingProgressListener()
-	}
-
-	if spec.FailFast {
-		listener = NewAbortOnErrorListener(listener)
+		return ui.NewProgressView(spec, terminalDimensionsOrFake, ctx)
 	}
 
-	return listener
+	return ui.NewLoggingProgressListener()
 }
 
 func enableTerminalGUI(cmd *cobra.Command, ctx api.IOContext) bool {
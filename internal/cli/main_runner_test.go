@@ -3,13 +3,17 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
 	"os/exec"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/results"
 	"github.com/sha1n/bert/pkg/specs"
 	gommonstest "github.com/sha1n/gommons/pkg/test"
 	"github.com/spf13/cobra"
@@ -153,6 +157,83 @@ func TestWithInvalidConfigFile(t *testing.T) {
 	runBenchmarkCommandWithPipedStdoutAndExpectPanicWith(t, invalidConfig)
 }
 
+func TestWithUndefinedBaseline(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutAndExpectPanicWith(t, itConfigFileArgValue, "--baseline", gommonstest.RandomString())
+}
+
+func TestBasicWithBaseline(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "NAME")
+		},
+		itConfigFileArgValue, "--baseline=NAME",
+	)
+}
+
+func TestWithFailingThresholds(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	ioContext := api.NewIOContext()
+	ioContext.StdoutWriter = outBuf
+	rootCmd := NewRootCommand(gommonstest.RandomString(), gommonstest.RandomString(), gommonstest.RandomString(), ioContext)
+	rootCmd.SetArgs([]string{"--config=../../test/data/thresholds_failing.yaml"})
+
+	defer func() {
+		o := recover()
+		assert.IsType(t, ThresholdsError{}, o)
+		if err, ok := o.(ThresholdsError); ok {
+			assert.Contains(t, err.Error(), "'NAME' mean")
+		}
+		assert.Contains(t, outBuf.String(), "failed •")
+	}()
+
+	_ = rootCmd.Execute()
+}
+
+func TestWithMaxTimeFailsThresholdsOfScenariosThatHaveNotStarted(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	ioContext := api.NewIOContext()
+	ioContext.StdoutWriter = outBuf
+	rootCmd := NewRootCommand(gommonstest.RandomString(), gommonstest.RandomString(), gommonstest.RandomString(), ioContext)
+	rootCmd.SetArgs([]string{"--config=../../test/data/max_time.yaml", "--max-time=500ms"})
+
+	defer func() {
+		o := recover()
+		assert.IsType(t, ThresholdsError{}, o)
+		if err, ok := o.(ThresholdsError); ok {
+			assert.Contains(t, err.Error(), "'fast' mean: no results")
+		}
+		assert.Regexp(t, `executions: \d of 5, max time reached`, outBuf.String())
+		assert.Contains(t, outBuf.String(), "executions: 0 of 5, max time reached")
+	}()
+
+	_ = rootCmd.Execute()
+}
+
+func TestWithRegressionThresholdsAndNoBaselineResults(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutAndExpectPanicWith(t, "--config=../../test/data/thresholds_regression.yaml")
+}
+
+func TestWithRegressionThresholdsAndBaselineResults(t *testing.T) {
+	resultsFilePath := path.Join(t.TempDir(), "baseline.json")
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) { assert.NoError(t, err) },
+		itConfigFileArgValue, "--save-results", resultsFilePath,
+	)
+
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "THRESHOLDS")
+			assert.Contains(t, stdout, "vs baseline")
+		},
+		"--config=../../test/data/thresholds_regression.yaml", "--baseline-results", resultsFilePath,
+	)
+}
+
 func TestWithCombinedDebugAndSilent(t *testing.T) {
 	runBenchmarkCommandWithPipedStdoutAndExpectPanicWith(t, "-s", "-d", itConfigFileArgValue)
 }
@@ -168,6 +249,425 @@ func Test_loadSpecWithExecutionsOverride(t *testing.T) {
 	assert.Equal(t, expectedSpec, spec)
 }
 
+func Test_loadSpecWithExecutionsOverrideDisablesAdaptiveExecution(t *testing.T) {
+	command := newDummyCommandWith("-c", "../../test/data/adaptive.yaml", "--executions", "3")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Adaptive)
+	assert.Equal(t, 3, spec.Executions)
+}
+
+func TestBasicAdaptive(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "executions: 2-5 (adaptive)")
+		},
+		"--config=../../test/data/adaptive.yaml",
+	)
+}
+
+func Test_loadSpecWithConcurrencyOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.Concurrency = 2 + rand.Intn(10)
+	command := newDummyCommandWith("-c", itConfigFilePath, "--concurrency", fmt.Sprint(expectedSpec.Concurrency))
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithPercentilesOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.Percentiles = []float64{50, 99.9}
+	command := newDummyCommandWith("-c", itConfigFilePath, "--percentiles", "50,99.9")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithInvalidPercentiles(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--percentiles", "50,101")
+
+	_, err := loadSpec(command, []string{})
+
+	assert.Error(t, err)
+}
+
+func Test_loadSpecWithTimeoutOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.Timeout = api.Duration(30 * time.Second)
+	command := newDummyCommandWith("-c", itConfigFilePath, "--timeout", "30s")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithNegativeTimeout(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--timeout=-1s")
+
+	_, err := loadSpec(command, []string{})
+
+	assert.Error(t, err)
+}
+
+func Test_loadSpecWithMaxLoadOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.MaxLoad = 2.5
+	expectedSpec.Settle = api.Duration(10 * time.Second)
+	command := newDummyCommandWith("-c", itConfigFilePath, "--max-load", "2.5", "--settle", "10s")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithNegativeMaxLoad(t *testing.T) {
+	_, err := loadSpec(newDummyCommandWith("-c", itConfigFilePath, "--max-load=-1"), []string{})
+	assert.Error(t, err)
+
+	_, err = loadSpec(newDummyCommandWith("-c", itConfigFilePath, "--max-load=1", "--settle=-1s"), []string{})
+	assert.Error(t, err)
+}
+
+func TestBasicWithMaxLoad(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "max load: 1000.00 (settle 1s)")
+		},
+		itConfigFileArgValue, "--executions=2", "--max-load=1000", "--settle=1s",
+	)
+}
+
+func Test_loadSpecWithMaxTimeOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.MaxTime = api.Duration(10 * time.Minute)
+	command := newDummyCommandWith("-c", itConfigFilePath, "--max-time", "10m")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithNegativeMaxTime(t *testing.T) {
+	_, err := loadSpec(newDummyCommandWith("-c", itConfigFilePath, "--max-time=-1s"), []string{})
+
+	assert.Error(t, err)
+}
+
+func TestBasicWithMaxTime(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "max time: 1h0m0s")
+		},
+		itConfigFileArgValue, "--executions=2", "--max-time=1h",
+	)
+}
+
+func TestWithTimeout(t *testing.T) {
+	runBenchmarkCommandAndExpectExecutionError(
+		t,
+		ExitCodeExecutionErrors,
+		func(stdout string) {
+			assert.Contains(t, stdout, "timeouts: 100%")
+		},
+		"sleep 10",
+		"--executions=1",
+		"--timeout=100ms",
+	)
+}
+
+func TestWithTimeoutReportsTheTimeToTheKill(t *testing.T) {
+	runBenchmarkCommandAndExpectExecutionError(
+		t,
+		ExitCodeExecutionErrors,
+		func(stdout string) {
+			var doc struct {
+				Records []struct {
+					Mean *int64 `json:"mean"`
+				} `json:"records"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+			if assert.Len(t, doc.Records, 1) && assert.NotNil(t, doc.Records[0].Mean) {
+				assert.GreaterOrEqual(t, time.Duration(*doc.Records[0].Mean), 100*time.Millisecond, "timed out runs are expected to be measured up to the kill")
+			}
+		},
+		"sleep 10",
+		"--executions=2",
+		"--timeout=100ms",
+		"--format=json",
+	)
+}
+
+func TestWithFailFast(t *testing.T) {
+	runBenchmarkCommandAndExpectExecutionError(
+		t,
+		ExitCodeAborted,
+		func(stdout string) {
+			assert.Contains(t, stdout, "errors: 100%", "the report is expected to be written")
+		},
+		"go no-such-go-command",
+		"--executions=3",
+		"--fail-fast",
+	)
+}
+
+func TestWithSkippedScenario(t *testing.T) {
+	runBenchmarkCommandAndExpectExecutionError(
+		t,
+		ExitCodeScenariosSkipped,
+		func(stdout string) {
+			assert.Contains(t, stdout, "broken cleanup")
+		},
+		"--config=../../test/data/error_policies.yaml",
+	)
+}
+
+func Test_loadSpecFromPositionalArgumentsWithShell(t *testing.T) {
+	command := newDummyCommandWith("--executions", "1", "--shell")
+
+	spec, err := loadSpec(command, []string{"ls -l | wc -l", "echo 'a' && echo \"b\""})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"ls -l | wc -l"}, Shell: api.DefaultShell}, spec.Scenarios[0].Command)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"echo 'a' && echo \"b\""}, Shell: api.DefaultShell}, spec.Scenarios[1].Command)
+}
+
+func Test_loadSpecFromPositionalArgumentsWithNamedShell(t *testing.T) {
+	command := newDummyCommandWith("--executions", "1", "--shell=bash", "--subtract-shell-overhead")
+
+	spec, err := loadSpec(command, []string{"ls | wc -l"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.Shell("bash"), spec.Scenarios[0].Command.Shell)
+	assert.True(t, spec.SubtractShellOverhead)
+}
+
+func TestBasicWithShell(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "shell overhead: subtracted")
+		},
+		"echo a | cat",
+		"--executions=2",
+		"--shell",
+		"--subtract-shell-overhead",
+	)
+}
+
+func Test_loadSpecWithVars(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--var", "a=1", "--var", "b=x=y", "--var", "c=")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "x=y", "c": ""}, spec.Vars)
+}
+
+func Test_loadSpecWithInvalidVar(t *testing.T) {
+	for _, v := range []string{"a", "=1"} {
+		command := newDummyCommandWith("-c", itConfigFilePath, "--var", v)
+
+		_, err := loadSpec(command, []string{})
+
+		assert.Error(t, err, v)
+	}
+}
+
+func Test_loadSpecWithOutputDir(t *testing.T) {
+	outputDir := path.Join(t.TempDir(), "nested", "out")
+	command := newDummyCommandWith("-c", itConfigFilePath, "--output-dir", outputDir)
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, outputDir, spec.OutputDir)
+	assert.DirExists(t, outputDir, "the output directory is expected to be created")
+}
+
+func Test_loadSpecWithScenarioFilter(t *testing.T) {
+	const specPath = "../../test/data/spec_test_load.yaml"
+	examples := map[string][]string{
+		"scenario A": {"--only", "scenario A"},
+		"scenario B": {"--only", "/^scenario/", "--skip", "*A"},
+	}
+
+	for expected, args := range examples {
+		command := newDummyCommandWith(append([]string{"-c", specPath}, args...)...)
+
+		spec, err := loadSpec(command, []string{})
+
+		assert.NoError(t, err, args)
+		assert.Len(t, spec.Scenarios, 1, args)
+		assert.Equal(t, expected, spec.Scenarios[0].Name, args)
+	}
+}
+
+func Test_loadSpecWithScenarioFilterOfParameterizedScenarios(t *testing.T) {
+	const specPath = "../../test/data/spec_test_load_sweep.yaml"
+	examples := map[string][]string{
+		"sweep (mode=fast, threads=1)": {"--only", "sweep (mode=fast, threads=1)"},
+		"sweep (mode=safe, threads=2)": {"--only", "/threads=\\d{1,3}\\)$/", "--skip", "*fast*", "--skip", "sweep (mode=safe, threads=1)"},
+	}
+
+	for expected, args := range examples {
+		command := newDummyCommandWith(append([]string{"-c", specPath}, args...)...)
+
+		spec, err := loadSpec(command, []string{})
+
+		assert.NoError(t, err, args)
+		if assert.Len(t, spec.Scenarios, 1, args) {
+			assert.Equal(t, expected, spec.Scenarios[0].Name, args)
+		}
+	}
+}
+
+func Test_loadSpecWithScenarioFilterWithoutMatches(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--tags", "undefined")
+
+	_, err := loadSpec(command, []string{})
+
+	assert.EqualError(t, err, "no scenarios match the filter 'tags=undefined'")
+}
+
+func Test_loadSpecWithCaptureOutput(t *testing.T) {
+	captureDir := path.Join(t.TempDir(), "nested", "captured")
+	command := newDummyCommandWith("-c", itConfigFilePath, "--capture-output", captureDir)
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, captureDir, spec.CaptureOutput)
+	assert.DirExists(t, captureDir, "the output capture directory is expected to be created")
+}
+
+func TestBasicWithCaptureOutput(t *testing.T) {
+	captureDir := t.TempDir()
+
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+		},
+		"echo captured",
+		"--executions=2",
+		"--shell",
+		"--capture-output", captureDir,
+	)
+
+	for _, name := range []string{"run-1.out", "run-2.out"} {
+		content, err := os.ReadFile(path.Join(captureDir, "[echo captured]", name))
+		assert.NoError(t, err)
+		assert.Equal(t, "captured\n", string(content))
+	}
+}
+
+func TestBasicWithSavedRunMetadata(t *testing.T) {
+	resultsFilePath := path.Join(t.TempDir(), "results.json")
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "RUN")
+		},
+		itConfigFileArgValue, "--executions=2", "--only=NAME", "--var=token=secret", "--var=empty=", "--save-results", resultsFilePath,
+	)
+
+	saved, err := results.Load(resultsFilePath)
+	assert.NoError(t, err)
+
+	metadata := saved.Metadata
+	assert.NotEmpty(t, metadata.Version)
+	assert.NotEmpty(t, metadata.Build)
+	assert.Equal(t, []string{"2"}, metadata.Flags[ArgNameExecutions])
+	assert.Equal(t, []string{"NAME"}, metadata.Flags[ArgNameOnly])
+	assert.Equal(t, []string{"token=<redacted>", "empty=<redacted>"}, metadata.Flags[ArgNameVar], "variable values are not expected to be recorded")
+	assert.Equal(t, []string{resultsFilePath}, metadata.Flags[ArgNameSaveResults])
+	assert.NotEmpty(t, metadata.Host.OS)
+	assert.False(t, metadata.StartTime.IsZero())
+	assert.False(t, metadata.EndTime.Before(metadata.StartTime))
+	assert.Positive(t, metadata.Duration)
+}
+
+func TestSavedResultsWithRedactedVariables(t *testing.T) {
+	resultsFilePath := path.Join(t.TempDir(), "results.json")
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+		},
+		itConfigFileArgValue, "--executions=1", "--var=token=supersecret", "--save-results", resultsFilePath,
+	)
+
+	content, err := os.ReadFile(resultsFilePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "supersecret", "variable values are not expected to be saved")
+
+	saved, err := results.Load(resultsFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "<redacted>", saved.Spec.Vars["token"])
+}
+
+func TestBasicWithVariables(t *testing.T) {
+	outputDir := t.TempDir()
+
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+		},
+		"touch ${BERT_OUTPUT_DIR}/${name}-${BERT_RUN_INDEX}-of-${BERT_RUN_TOTAL}",
+		"--executions=2",
+		"--shell",
+		"--var", "name=run",
+		"--output-dir", outputDir,
+	)
+
+	assert.FileExists(t, path.Join(outputDir, "run-1-of-2"))
+	assert.FileExists(t, path.Join(outputDir, "run-2-of-2"))
+}
+
+func TestBasicWithRemoveOutliers(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "outliers: removed")
+		},
+		itConfigFileArgValue,
+		"--remove-outliers",
+	)
+}
+
+func TestBasicConcurrent(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(
+		t,
+		func(stdout, stderr string, err error) {
+			assert.NoError(t, err)
+			assert.Contains(t, stdout, "NAME")
+		},
+		itConfigFileArgValue,
+		"--concurrency=3",
+		"--alternate",
+	)
+}
+
 func Test_loadSpecWithAlternateOverride(t *testing.T) {
 	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
 	expectedSpec.Alternate = true
@@ -179,6 +679,89 @@ func Test_loadSpecWithAlternateOverride(t *testing.T) {
 	assert.Equal(t, expectedSpec, spec)
 }
 
+func Test_loadSpecWithOrderOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.Order = api.OrderShuffle
+	expectedSpec.Seed = 1234
+	command := newDummyCommandWith("-c", itConfigFilePath, "--order", "shuffle", "--seed", "1234")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithRandomOrderGeneratesSeed(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--order", "random")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.OrderRandom, spec.ExecutionOrder())
+	assert.NotZero(t, spec.Seed)
+}
+
+func Test_loadSpecWithInvalidOrder(t *testing.T) {
+	for _, args := range [][]string{{"--order", "reverse"}, {"--order", "random", "--alternate"}} {
+		command := newDummyCommandWith(append([]string{"-c", itConfigFilePath}, args...)...)
+
+		_, err := loadSpec(command, []string{})
+
+		assert.Error(t, err, args)
+	}
+}
+
+func TestBasicWithRandomOrder(t *testing.T) {
+	runBenchmarkCommandWithPipedStdoutputsAnd(t, func(stdout, stderr string, err error) {
+		assert.NoError(t, err)
+		assert.Contains(t, stdout, "order: random")
+		assert.Contains(t, stdout, "seed: 42")
+	}, "-c", itConfigFilePath, "--order", "random", "--seed", "42")
+}
+
+func Test_loadSpecWithWarmupOverride(t *testing.T) {
+	expectedSpec, _ := specs.LoadSpec(itConfigFilePath)
+	expectedSpec.Warmup = 1 + rand.Intn(10)
+	command := newDummyCommandWith("-c", itConfigFilePath, "--warmup", fmt.Sprint(expectedSpec.Warmup))
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSpec, spec)
+}
+
+func Test_loadSpecWithZeroWarmupOverride(t *testing.T) {
+	const example = `executions: 1
+warmup: 3
+scenarios:
+- name: inherits
+  command:
+    cmd: [test]
+- name: overrides
+  warmup: 2
+  command:
+    cmd: [test]
+`
+	specPath := path.Join(t.TempDir(), "warmup.yaml")
+	assert.NoError(t, os.WriteFile(specPath, []byte(example), 0644))
+	command := newDummyCommandWith("-c", specPath, "--warmup", "0")
+
+	spec, err := loadSpec(command, []string{})
+
+	assert.NoError(t, err)
+	for _, scenario := range spec.Scenarios {
+		assert.Zero(t, spec.WarmupExecutions(scenario), scenario.Name)
+	}
+}
+
+func Test_loadSpecWithNegativeWarmup(t *testing.T) {
+	command := newDummyCommandWith("-c", itConfigFilePath, "--warmup", "-1")
+
+	_, err := loadSpec(command, []string{})
+
+	assert.Error(t, err)
+}
+
 func Test_loadSpecFromPositionalArguments(t *testing.T) {
 	expectedExecutions := rand.Intn(100)
 	expectedValidSpec, _ := specs.CreateSpecFrom(
@@ -326,6 +909,26 @@ func runBenchmarkCommandWithPipedStdoutAndExpectPanicWith(t *testing.T, args ...
 	assert.Panics(t, func() { _ = rootCmd.Execute() })
 }
 
+func runBenchmarkCommandAndExpectExecutionError(t *testing.T, expectedExitCode int, assertOutput func(stdout string), args ...string) {
+	outBuf := new(bytes.Buffer)
+	ioContext := api.NewIOContext()
+	ioContext.StdoutWriter = outBuf
+	ioContext.StderrWriter = new(bytes.Buffer)
+	rootCmd := NewRootCommand(gommonstest.RandomString(), gommonstest.RandomString(), gommonstest.RandomString(), ioContext)
+	rootCmd.SetArgs(args)
+
+	defer func() {
+		o := recover()
+		assert.IsType(t, ExecutionError{}, o)
+		if err, ok := o.(ExecutionError); ok {
+			assert.Equal(t, expectedExitCode, err.ExitCode())
+		}
+		assertOutput(outBuf.String())
+	}()
+
+	_ = rootCmd.Execute()
+}
+
 func expectNoPanic(t *testing.T) {
 	if o := recover(); o != nil {
 		if err, ok := o.(error); ok {
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -45,7 +46,7 @@ func (rw CsvStreamReportWriter) Handle(trace api.Trace) (err error) {
 	defer rw.writer.Flush()
 
 	timeStr := FormatDateTime(time.Now(), rw.ctx)
-	err = rw.writer.Write([]string{
+	record := []string{
 		timeStr,
 		trace.ID(),
 		strings.Join(rw.ctx.Labels, ","),
@@ -53,7 +54,12 @@ func (rw CsvStreamReportWriter) Handle(trace api.Trace) (err error) {
 		fmt.Sprintf("%d", trace.UserCPUTime()),
 		fmt.Sprintf("%d", trace.SystemCPUTime()),
 		fmt.Sprintf("%v", trace.Error() != nil),
-	})
+		fmt.Sprintf("%v", errors.Is(trace.Error(), api.ErrTimeout)),
+		fmt.Sprintf("%d", trace.Wait()),
+	}
+	record = append(record, FormatReportUsage(func() (api.ResourceUsage, error) { return trace.ResourceUsage(), nil }, FormatReportBytesPlain)...)
+
+	err = rw.writer.Write(record)
 
 	return err
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"errors"
 	"io"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -22,7 +23,10 @@ func TestHandle(t *testing.T) {
 	// Headers
 	assert.Equal(
 		t,
-		[]string{"Timestamp", "Scenario", "Labels", "Duration", "User Time", "System Time", "Error"},
+		[]string{
+			"Timestamp", "Scenario", "Labels", "Duration", "User Time", "System Time", "Error", "Timeout", "Wait",
+			"Max RSS", "Minor Page Faults", "Major Page Faults", "Voluntary Context Switches", "Involuntary Context Switches", "Block Input Ops", "Block Output Ops",
+		},
 		allRecords[0],
 	)
 
@@ -41,6 +45,16 @@ func TestHandleWithoutHeaders(t *testing.T) {
 	assertRawTraceRecord(t, t2, allRecords[1])
 }
 
+func TestHandleWithWait(t *testing.T) {
+	trace := NewFakeTraceWithWait(gommonstest.RandomString(), time.Second, 2*time.Second)
+
+	allRecords := writeCsvRawReport(t, false, trace)
+
+	assert.Equal(t, 1, len(allRecords))
+	assert.Equal(t, "2000000000", allRecords[0][8])
+	assertRawTraceRecord(t, trace, allRecords[0])
+}
+
 func writeCsvRawReport(t *testing.T, includeHeaders bool, traces ...api.Trace) [][]string {
 	return writeRawReport(t,
 		NewCsvStreamReportWriter,
@@ -54,12 +68,10 @@ func writeCsvRawReport(t *testing.T, includeHeaders bool, traces ...api.Trace) [
 }
 
 func twoRandomTraceEvents() (api.Trace, api.Trace) {
-	return NewFakeTrace(
+	return NewFakeTraceWithResourceUsage(
 			gommonstest.RandomString(),
 			time.Duration(gommonstest.RandomUint()),
-			time.Duration(gommonstest.RandomUint()),
-			time.Duration(gommonstest.RandomUint()),
-			nil,
+			aRandomResourceUsage(),
 		),
 		NewFakeTrace(
 			gommonstest.RandomString(),
@@ -69,3 +81,15 @@ func twoRandomTraceEvents() (api.Trace, api.Trace) {
 			errors.New(gommonstest.RandomString()),
 		)
 }
+
+func aRandomResourceUsage() api.ResourceUsage {
+	return api.ResourceUsage{
+		MaxRSS:                     rand.Int63n(1 << 32),
+		MinorPageFaults:            rand.Int63n(10000),
+		MajorPageFaults:            rand.Int63n(100),
+		VoluntaryContextSwitches:   rand.Int63n(1000),
+		InvoluntaryContextSwitches: rand.Int63n(1000),
+		BlockInputOps:              rand.Int63n(1000),
+		BlockOutputOps:             rand.Int63n(1000),
+	}
+}
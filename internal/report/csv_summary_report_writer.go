@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"time"
 
 	"encoding/csv"
 
@@ -29,12 +28,17 @@ func (rw csvReportWriter) Write(summary api.Summary, config api.BenchmarkSpec, c
 	defer rw.writer.Flush()
 
 	if ctx.IncludeHeaders {
-		if err = rw.writer.Write(SummaryReportHeaders); err != nil {
+		if err = rw.writer.Write(append(SummaryReportHeaders(config.ReportPercentiles()), csvSummaryReportOrderHeaders...)); err != nil {
 			return err
 		}
 	}
 
 	timeStr := FormatDateTime(summary.Time(), ctx)
+	// the order columns are empty when the order isn't random, so that all the rows of accumulated reports have the same columns
+	orderRecord := make([]string, len(csvSummaryReportOrderHeaders))
+	for i, property := range GetExecutionOrderProperties(config) {
+		orderRecord[i] = property.Value
+	}
 	sortedIds := GetSortedScenarioIds(summary)
 
 	for _, id := range sortedIds {
@@ -42,7 +46,7 @@ func (rw csvReportWriter) Write(summary api.Summary, config api.BenchmarkSpec, c
 		userStats := summary.UserTimeStats(id)
 		systemStats := summary.SystemTimeStats(id)
 
-		if err = rw.writer.Write([]string{
+		record := []string{
 			timeStr,
 			id,
 			fmt.Sprintf("%d", stats.Count()),
@@ -51,12 +55,21 @@ func (rw csvReportWriter) Write(summary api.Summary, config api.BenchmarkSpec, c
 			FormatReportDurationPlainNanos(stats.Max),
 			FormatReportDurationPlainNanos(stats.Mean),
 			FormatReportDurationPlainNanos(stats.Median),
-			FormatReportDurationPlainNanos(func() (time.Duration, error) { return stats.Percentile(90) }),
+		}
+		for _, p := range config.ReportPercentiles() {
+			record = append(record, FormatReportDurationPlainNanos(percentileOf(stats, p)))
+		}
+		record = append(record,
 			FormatReportDurationPlainNanos(stats.StdDev),
 			FormatReportDurationPlainNanos(userStats.Mean),
 			FormatReportDurationPlainNanos(systemStats.Mean),
 			FormatReportFloatAsRateInPercents(stats.ErrorRate),
-		}); err != nil {
+			FormatReportFloatAsRateInPercents(stats.TimeoutRate),
+		)
+		record = append(record, FormatReportUsage(summary.ResourceUsageStats(id).Mean, FormatReportBytesPlain)...)
+		record = append(record, orderRecord...)
+
+		if err = rw.writer.Write(record); err != nil {
 			return err
 		}
 	}
@@ -54,6 +54,16 @@ func TestWrite(t *testing.T) {
 			"User Time",
 			"System Time",
 			"Errors",
+			"Timeouts",
+			"Max RSS",
+			"Minor Page Faults",
+			"Major Page Faults",
+			"Voluntary Context Switches",
+			"Involuntary Context Switches",
+			"Block Input Ops",
+			"Block Output Ops",
+			"Order",
+			"Seed",
 		},
 		allRecords[0],
 	)
@@ -93,6 +103,21 @@ func TestWriteWithNoHeaders(t *testing.T) {
 	assertRecord(t, scenario2, summary, expectedTimestamp, allRecords[1])
 }
 
+func TestWriteWithPercentiles(t *testing.T) {
+	summary := aComparableSummary()
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewCsvReportWriter(buf)(summary, api.BenchmarkSpec{Percentiles: []float64{50, 75, 99.9}}, api.ReportContext{IncludeHeaders: true}))
+	allRecords, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Percentile 50", "Percentile 75", "Percentile 99.9", "StdDev"}, allRecords[0][8:12])
+	stats := summary.PerceivedTimeStats(allRecords[1][1])
+	assert.Equal(t, FormatReportDurationPlainNanos(func() (time.Duration, error) { return stats.Percentile(50) }), allRecords[1][8])
+	assert.Equal(t, FormatReportDurationPlainNanos(func() (time.Duration, error) { return stats.Percentile(99.9) }), allRecords[1][10])
+	assert.Equal(t, FormatReportDurationPlainNanos(stats.StdDev), allRecords[1][11])
+}
+
 func assertRecord(t *testing.T, scenario api.Identifiable, summary api.Summary, expectedTimestamp string, actualRecord []string) {
 	stats := summary.PerceivedTimeStats(scenario.ID())
 	userStats := summary.UserTimeStats(scenario.ID())
@@ -112,6 +137,10 @@ func assertRecord(t *testing.T, scenario api.Identifiable, summary api.Summary,
 	assert.Equal(t, FormatReportDurationPlainNanos(userStats.Mean), actualRecord[10])
 	assert.Equal(t, FormatReportDurationPlainNanos(systemStats.Mean), actualRecord[11])
 	assert.Equal(t, expectedRateFormat(stats.ErrorRate), actualRecord[12])
+	assert.Equal(t, expectedRateFormat(stats.TimeoutRate), actualRecord[13])
+	expectedUsage, _ := summary.ResourceUsageStats(scenario.ID()).Mean()
+	assertUsageRecord(t, expectedUsage, FormatReportBytesPlain, actualRecord[14:21])
+	assert.Equal(t, []string{"", ""}, actualRecord[21:], "the order columns are expected to be empty when the order isn't random")
 }
 
 func expectedIntFormat(f func() int) string {
@@ -125,6 +154,19 @@ func expectedRateFormat(f func() float64) string {
 
 }
 
+func TestWriteWithRandomOrder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	spec := api.BenchmarkSpec{Order: api.OrderRandom, Seed: 1234}
+
+	assert.NoError(t, NewCsvReportWriter(buf)(aComparableSummary(), spec, api.ReportContext{IncludeHeaders: true}))
+
+	allRecords, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	for _, record := range allRecords[1:] {
+		assert.Equal(t, []string{"random", "1234"}, record[len(record)-2:])
+	}
+}
+
 func writeCsvReport(t *testing.T, summary api.Summary, includeHeaders bool) [][]string {
 	buf := new(bytes.Buffer)
 
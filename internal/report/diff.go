@@ -0,0 +1,115 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sha1n/bert/api"
+)
+
+// NotApplicableValue is rendered in place of values that do not apply to a report cell
+const NotApplicableValue = "-"
+
+// DiffReportHeaders ...
+var DiffReportHeaders = []string{
+	"Scenario",
+	"Run",
+	"Samples",
+	"Mean",
+	"Median",
+	"StdDev",
+	"Errors",
+	"Ratio",
+	"CI Lower",
+	"CI Upper",
+	"P-Value",
+	"Change",
+}
+
+// ComparedRun a named summary of a benchmark run
+type ComparedRun struct {
+	Name    string
+	Summary api.Summary
+}
+
+// WriteDiffReportFn a report handler that compares benchmark runs against the first run
+type WriteDiffReportFn = func([]ComparedRun, api.ReportContext) error
+
+// diffRecord represents the stats of one scenario in one run. The comparison of the first run is always nil.
+type diffRecord struct {
+	id         api.ID
+	run        string
+	stats      api.Stats
+	comparison api.Comparison
+}
+
+// getDiffRecords returns diff records sorted by scenario ID and then by run order.
+// Scenarios that are missing from a run are skipped for that run.
+func getDiffRecords(runs []ComparedRun) []diffRecord {
+	records := []diffRecord{}
+	if len(runs) == 0 {
+		return records
+	}
+
+	baseline := runs[0].Summary
+	for _, id := range getSortedUnionOfScenarioIds(runs) {
+		for i, run := range runs {
+			stats := run.Summary.PerceivedTimeStats(id)
+			if stats == nil {
+				continue
+			}
+
+			record := diffRecord{id: id, run: run.Name, stats: stats}
+			if i > 0 && baseline.PerceivedTimeStats(id) != nil {
+				record.comparison = run.Summary.PerceivedTimeComparison(id, baseline.PerceivedTimeStats(id))
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+func getSortedUnionOfScenarioIds(runs []ComparedRun) []api.ID {
+	idSet := map[api.ID]bool{}
+	for _, run := range runs {
+		for _, id := range run.Summary.IDs() {
+			idSet[id] = true
+		}
+	}
+
+	ids := make([]api.ID, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (r diffRecord) toRow() []string {
+	row := []string{
+		r.id,
+		r.run,
+		fmt.Sprint(r.stats.Count()),
+		FormatReportDuration(r.stats.Mean),
+		FormatReportDuration(r.stats.Median),
+		FormatReportDuration(r.stats.StdDev),
+		FormatReportFloatAsRateInPercents(r.stats.ErrorRate),
+	}
+
+	if r.comparison == nil {
+		return append(row, NotApplicableValue, NotApplicableValue, NotApplicableValue, NotApplicableValue, NotApplicableValue)
+	}
+
+	lower, upper := FormatReportRatioConfidenceInterval(r.comparison)
+
+	return append(row,
+		FormatReportRatio(r.comparison.Ratio),
+		lower,
+		upper,
+		FormatReportPValue(r.comparison.PValue),
+		DescribeComparison(r.comparison),
+	)
+}
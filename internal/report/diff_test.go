@@ -0,0 +1,71 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextDiffReport(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextDiffReportWriter(buf)(aComparedRuns(), api.ReportContext{IncludeHeaders: true}))
+
+	text := buf.String()
+	assert.Contains(t, text, "baseline: main")
+	assert.Contains(t, text, "Scenario")
+	assert.Contains(t, text, "P-Value")
+	assert.Contains(t, text, "2.00x slower")
+	assert.Equal(t, 3, strings.Count(text, "scenario"), "a row per run for the shared scenario and one for the unique scenario")
+}
+
+func TestMarkdownDiffReport(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewMarkdownDiffReportWriter(buf)(aComparedRuns(), api.ReportContext{IncludeHeaders: true}))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Equal(t, 2 /*header + sep*/ +3 /*data*/ +1 /*CRLF*/, len(lines))
+	assert.Equal(t, "|"+strings.Join(DiffReportHeaders, "|")+"|", lines[0])
+	assert.True(t, strings.HasPrefix(lines[2], "|scenario|main|10|"))
+	assert.True(t, strings.HasSuffix(lines[2], "|-|-|-|-|-|"))
+	assert.True(t, strings.HasPrefix(lines[3], "|scenario|feature|10|"))
+	assert.True(t, strings.HasSuffix(lines[3], "|2.00x slower|"))
+	assert.True(t, strings.HasPrefix(lines[4], "|unique scenario|feature|10|"))
+}
+
+func TestJSONDiffReport(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewJSONDiffReportWriter(buf)(aComparedRuns(), api.ReportContext{}))
+
+	var doc jsonDiffReportDocument
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "main", doc.Baseline)
+	assert.Equal(t, 3, len(doc.Records))
+	assert.Nil(t, doc.Records[0].Ratio)
+	assert.Equal(t, 2.0, *doc.Records[1].Ratio)
+	assert.NotNil(t, doc.Records[1].PValue)
+	assert.Nil(t, doc.Records[2].Ratio)
+}
+
+func aComparedRuns() []ComparedRun {
+	mainTraces, featureTraces := []api.Trace{}, []api.Trace{}
+	for i := 1; i <= 10; i++ {
+		mainTraces = append(mainTraces, NewFakeTrace("scenario", time.Duration(100+i), 1, 1, nil))
+		featureTraces = append(featureTraces,
+			NewFakeTrace("scenario", time.Duration(200+2*i), 1, 1, nil),
+			NewFakeTrace("unique scenario", time.Duration(i), 1, 1, nil),
+		)
+	}
+
+	return []ComparedRun{
+		{Name: "main", Summary: NewFakeSummary(mainTraces...)},
+		{Name: "feature", Summary: NewFakeSummary(featureTraces...)},
+	}
+}
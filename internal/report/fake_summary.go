@@ -12,6 +12,8 @@ type fakeTrace struct {
 	perceivedTime time.Duration
 	usrCPUTime    time.Duration
 	sysCPUTime    time.Duration
+	resourceUsage api.ResourceUsage
+	wait          time.Duration
 	error         error
 }
 
@@ -31,6 +33,18 @@ func (t fakeTrace) UserCPUTime() time.Duration {
 	return t.usrCPUTime
 }
 
+func (t fakeTrace) ResourceUsage() api.ResourceUsage {
+	return t.resourceUsage
+}
+
+func (t fakeTrace) Concurrency() int {
+	return 1
+}
+
+func (t fakeTrace) Wait() time.Duration {
+	return t.wait
+}
+
 func (t fakeTrace) Error() error {
 	return t.error
 }
@@ -46,6 +60,24 @@ func NewFakeTrace(id string, elapsed, userTime, sysTime time.Duration, err error
 	}
 }
 
+// NewFakeTraceWithResourceUsage creates a fake trace with the specified data and resource usage
+func NewFakeTraceWithResourceUsage(id string, elapsed time.Duration, usage api.ResourceUsage) api.Trace {
+	return &fakeTrace{
+		id:            id,
+		perceivedTime: elapsed,
+		resourceUsage: usage,
+	}
+}
+
+// NewFakeTraceWithWait creates a fake trace of a run that waited the specified time before it started
+func NewFakeTraceWithWait(id string, elapsed, wait time.Duration) api.Trace {
+	return &fakeTrace{
+		id:            id,
+		perceivedTime: elapsed,
+		wait:          wait,
+	}
+}
+
 // NewFakeSummary creates a new fake summary object with the specified trace events
 func NewFakeSummary(traces ...api.Trace) api.Summary {
 	traceByID := map[string][]api.Trace{}
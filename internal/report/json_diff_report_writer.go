@@ -0,0 +1,76 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/sha1n/bert/api"
+)
+
+// jsonDiffReportWriter a JSON diff report writer
+type jsonDiffReportWriter struct {
+	writer io.Writer
+}
+
+// NewJSONDiffReportWriter returns a JSON diff report write handler.
+func NewJSONDiffReportWriter(writer io.Writer) WriteDiffReportFn {
+	w := jsonDiffReportWriter{
+		writer: writer,
+	}
+
+	return w.Write
+}
+
+func (rw jsonDiffReportWriter) Write(runs []ComparedRun, ctx api.ReportContext) (err error) {
+	doc := jsonDiffReportDocument{
+		Records: []jsonDiffReportRecord{},
+	}
+	if len(runs) > 0 {
+		doc.Baseline = runs[0].Name
+	}
+
+	for _, r := range getDiffRecords(runs) {
+		errorRate := r.stats.ErrorRate()
+		record := jsonDiffReportRecord{
+			Name:       r.id,
+			Run:        r.run,
+			Executions: r.stats.Count(),
+			Mean:       floatValueNanos(r.stats.Mean),
+			Median:     floatValueNanos(r.stats.Median),
+			Stddev:     floatValueNanos(r.stats.StdDev),
+			ErrorRate:  &errorRate,
+		}
+
+		if r.comparison != nil {
+			record.Ratio = floatValue(r.comparison.Ratio)
+			record.PValue = floatValue(r.comparison.PValue)
+			if lower, upper, err := r.comparison.RatioConfidenceInterval(ComparisonConfidenceLevel); err == nil {
+				record.RatioCILower, record.RatioCIUpper = &lower, &upper
+			}
+		}
+
+		doc.Records = append(doc.Records, record)
+	}
+
+	encoder := json.NewEncoder(rw.writer)
+	return encoder.Encode(doc)
+}
+
+type jsonDiffReportDocument struct {
+	Baseline string                 `json:"baseline,omitempty"`
+	Records  []jsonDiffReportRecord `json:"records"`
+}
+
+type jsonDiffReportRecord struct {
+	Name         string   `json:"name,omitempty"`
+	Run          string   `json:"run,omitempty"`
+	Executions   int      `json:"executions,omitempty"`
+	Mean         *int64   `json:"mean,omitempty"`
+	Median       *int64   `json:"median,omitempty"`
+	Stddev       *int64   `json:"stddev,omitempty"`
+	ErrorRate    *float64 `json:"errorRate,omitempty"`
+	Ratio        *float64 `json:"ratio,omitempty"`
+	RatioCILower *float64 `json:"ratioCILower,omitempty"`
+	RatioCIUpper *float64 `json:"ratioCIUpper,omitempty"`
+	PValue       *float64 `json:"pValue,omitempty"`
+}
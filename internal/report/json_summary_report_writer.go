@@ -27,6 +27,13 @@ func (rw jsonReportWriter) Write(summary api.Summary, config api.BenchmarkSpec,
 	doc := jsonSummaryReportDocument{
 		Records: make([]jsonSummaryReportRecord, len(summary.IDs())),
 	}
+	if order := config.ExecutionOrder(); order.IsRandom() {
+		doc.Order, doc.Seed = order, &config.Seed
+	}
+	if !ctx.Filter.IsEmpty() {
+		doc.Filter = &ctx.Filter
+	}
+	doc.Metadata = ctx.Metadata
 
 	sortedIds := GetSortedScenarioIds(summary)
 
@@ -36,21 +43,62 @@ func (rw jsonReportWriter) Write(summary api.Summary, config api.BenchmarkSpec,
 		sysStats := summary.SystemTimeStats(id)
 
 		errorRate := float64(stats.ErrorRate())
+		timeoutRate := stats.TimeoutRate()
 		doc.Records[index] = jsonSummaryReportRecord{
-			Timestamp:  summary.Time().UTC(),
-			Name:       id,
-			Executions: stats.Count(),
-			Labels:     ctx.Labels,
-			Min:        floatValueNanos(stats.Min),
-			Max:        floatValueNanos(stats.Max),
-			Mean:       floatValueNanos(stats.Mean),
-			Stddev:     floatValueNanos(stats.StdDev),
-			Median:     floatValueNanos(stats.Median),
-			P90:        floatValueNanos(func() (time.Duration, error) { return stats.Percentile(90) }),
-			User:       floatValueNanos(userStats.Mean),
-			System:     floatValueNanos(sysStats.Mean),
-			ErrorRate:  &errorRate,
+			Timestamp:         summary.Time().UTC(),
+			Name:              id,
+			Executions:        stats.Count(),
+			Labels:            ctx.Labels,
+			Min:               floatValueNanos(stats.Min),
+			Max:               floatValueNanos(stats.Max),
+			Mean:              floatValueNanos(stats.Mean),
+			Stddev:            floatValueNanos(stats.StdDev),
+			Median:            floatValueNanos(stats.Median),
+			P90:               floatValueNanos(percentileOf(stats, 90)),
+			MeanCIHalfWidth:   floatValueNanos(ConfidenceHalfWidth(stats.MeanConfidenceInterval)),
+			MedianCIHalfWidth: floatValueNanos(ConfidenceHalfWidth(stats.MedianConfidenceInterval)),
+			Percentiles:       map[string]*int64{},
+			User:              floatValueNanos(userStats.Mean),
+			System:            floatValueNanos(sysStats.Mean),
+			ErrorRate:         &errorRate,
+			TimeoutRate:       &timeoutRate,
+			Outliers:          stats.Outliers(),
+		}
+		for _, p := range config.ReportPercentiles() {
+			doc.Records[index].Percentiles[PercentileName(p)] = floatValueNanos(percentileOf(stats, p))
+		}
+		if usage, err := summary.ResourceUsageStats(id).Mean(); err == nil {
+			doc.Records[index].ResourceUsage = &usage
+		}
+	}
+
+	for _, id := range GetSortedComparedScenarioIds(summary, ctx.Baseline) {
+		comparison := NewPerceivedTimeComparison(summary, ctx.Baseline, id)
+		record := jsonComparisonRecord{
+			Name:     id,
+			Baseline: ctx.Baseline,
+			Ratio:    floatValue(comparison.Ratio),
+			PValue:   floatValue(comparison.PValue),
 		}
+		if lower, upper, err := comparison.RatioConfidenceInterval(ComparisonConfidenceLevel); err == nil {
+			record.RatioCILower, record.RatioCIUpper = &lower, &upper
+		}
+
+		doc.Comparisons = append(doc.Comparisons, record)
+	}
+
+	for _, group := range GetParameterGroups(config) {
+		record := jsonParameterRecord{Name: group.Name}
+		for _, value := range group.Values {
+			record.Values = append(record.Values, jsonParameterValueRecord{
+				Value:     value.Value,
+				Scenarios: value.IDs,
+				Mean:      floatValueNanos(func() (time.Duration, error) { return value.Mean(summary) }),
+				Ratio:     floatValue(func() (float64, error) { return value.Ratio(summary, group.Values[0]) }),
+			})
+		}
+
+		doc.Parameters = append(doc.Parameters, record)
 	}
 
 	encoder := json.NewEncoder(rw.writer)
@@ -67,8 +115,46 @@ func floatValueNanos(f func() (time.Duration, error)) (v *int64) {
 	return
 }
 
+func floatValue(f func() (float64, error)) (v *float64) {
+	value, err := f()
+	if err == nil {
+		v = &value
+	}
+
+	return
+}
+
 type jsonSummaryReportDocument struct {
-	Records []jsonSummaryReportRecord `json:"records,omitempty"`
+	Order       api.ExecutionOrder        `json:"order,omitempty"`
+	Seed        *int64                    `json:"seed,omitempty"`
+	Filter      *api.ScenarioFilter       `json:"filter,omitempty"`
+	Metadata    *api.RunMetadata          `json:"metadata,omitempty"`
+	Records     []jsonSummaryReportRecord `json:"records,omitempty"`
+	Comparisons []jsonComparisonRecord    `json:"comparisons,omitempty"`
+	Parameters  []jsonParameterRecord     `json:"parameters,omitempty"`
+}
+
+type jsonParameterRecord struct {
+	Name   string                     `json:"name"`
+	Values []jsonParameterValueRecord `json:"values"`
+}
+
+type jsonParameterValueRecord struct {
+	Value     string   `json:"value"`
+	Scenarios []string `json:"scenarios"`
+	// Mean the mean of the scenario means
+	Mean *int64 `json:"mean,omitempty"`
+	// Ratio the ratio between the mean and the mean of the first value of the parameter
+	Ratio *float64 `json:"ratio,omitempty"`
+}
+
+type jsonComparisonRecord struct {
+	Name         string   `json:"name,omitempty"`
+	Baseline     string   `json:"baseline,omitempty"`
+	Ratio        *float64 `json:"ratio,omitempty"`
+	RatioCILower *float64 `json:"ratioCILower,omitempty"`
+	RatioCIUpper *float64 `json:"ratioCIUpper,omitempty"`
+	PValue       *float64 `json:"pValue,omitempty"`
 }
 
 type jsonSummaryReportRecord struct {
@@ -81,8 +167,19 @@ type jsonSummaryReportRecord struct {
 	Mean       *int64    `json:"mean,omitempty"`
 	Stddev     *int64    `json:"stddev,omitempty"`
 	Median     *int64    `json:"median,omitempty"`
-	P90        *int64    `json:"p90,omitempty"`
-	User       *int64    `json:"user,omitempty"`
-	System     *int64    `json:"system,omitempty"`
-	ErrorRate  *float64  `json:"errorRate,omitempty"`
+	// P90 the 90th percentile, which is reported regardless of the reported percentiles, for backward compatibility
+	P90 *int64 `json:"p90,omitempty"`
+	// MeanCIHalfWidth the half-width of the 95% confidence interval of the mean
+	MeanCIHalfWidth *int64 `json:"meanCIHalfWidth,omitempty"`
+	// MedianCIHalfWidth the half-width of the 95% confidence interval of the median
+	MedianCIHalfWidth *int64 `json:"medianCIHalfWidth,omitempty"`
+	// Percentiles the reported percentiles by name, e.g. 'p90' or 'p99.9'
+	Percentiles map[string]*int64 `json:"percentiles,omitempty"`
+	User        *int64            `json:"user,omitempty"`
+	System      *int64            `json:"system,omitempty"`
+	ErrorRate   *float64          `json:"errorRate,omitempty"`
+	TimeoutRate *float64          `json:"timeoutRate,omitempty"`
+	Outliers    int               `json:"outliers"`
+	// ResourceUsage the mean resource usage per execution
+	ResourceUsage *api.ResourceUsage `json:"resourceUsage,omitempty"`
 }
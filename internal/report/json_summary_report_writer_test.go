@@ -30,13 +30,103 @@ func Test_jsonReportWriter_Write(t *testing.T) {
 
 		assert.Equal(t, record.Executions, perceivedStats.Count())
 		assert.Equal(t, *record.ErrorRate, perceivedStats.ErrorRate())
+		assert.Equal(t, *record.TimeoutRate, perceivedStats.TimeoutRate())
 		assertStatEqual(t, record.Min, perceivedStats.Min)
 		assertStatEqual(t, record.Max, perceivedStats.Max)
 		assertStatEqual(t, record.Mean, perceivedStats.Mean)
 		assertStatEqual(t, record.Stddev, perceivedStats.StdDev)
+		assert.Equal(t, 1, len(record.Percentiles))
+		assertStatEqual(t, record.Percentiles["p90"], func() (time.Duration, error) { return perceivedStats.Percentile(90) })
 		assertStatEqual(t, record.P90, func() (time.Duration, error) { return perceivedStats.Percentile(90) })
 		assertStatEqual(t, record.User, userStats.Mean)
 		assertStatEqual(t, record.System, systemStats.Mean)
+
+		expectedUsage, _ := summary.ResourceUsageStats(record.Name).Mean()
+		assert.Equal(t, &expectedUsage, record.ResourceUsage)
+	}
+}
+
+func Test_jsonReportWriter_WriteComparisons(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+
+	assert.NoError(t, writeFn(aComparableSummary(), api.BenchmarkSpec{}, api.ReportContext{Baseline: "fast"}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	assert.Equal(t, 1, len(reportDocument.Comparisons))
+	comparison := reportDocument.Comparisons[0]
+	assert.Equal(t, "slow", comparison.Name)
+	assert.Equal(t, "fast", comparison.Baseline)
+	assert.Equal(t, 2.0, *comparison.Ratio)
+	assert.Less(t, *comparison.RatioCILower, 2.0)
+	assert.Greater(t, *comparison.RatioCIUpper, 2.0)
+	assert.Less(t, *comparison.PValue, ComparisonSignificanceLevel)
+}
+
+func Test_jsonReportWriter_WriteSeed(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+
+	assert.NoError(t, writeFn(aComparableSummary(), api.BenchmarkSpec{Order: api.OrderRandom, Seed: 1234}, api.ReportContext{}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	assert.Equal(t, api.OrderRandom, reportDocument.Order)
+	assert.Equal(t, int64(1234), *reportDocument.Seed)
+}
+
+func Test_jsonReportWriter_WriteFilter(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+	filter := api.ScenarioFilter{Tags: []string{"fast"}}
+
+	assert.NoError(t, writeFn(aComparableSummary(), api.BenchmarkSpec{}, api.ReportContext{Filter: filter}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	assert.Equal(t, &filter, reportDocument.Filter)
+}
+
+func Test_jsonReportWriter_WriteRunMetadata(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+	metadata := aRunMetadata()
+
+	assert.NoError(t, writeFn(aComparableSummary(), api.BenchmarkSpec{}, api.ReportContext{Metadata: metadata}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	assert.Equal(t, metadata, reportDocument.Metadata)
+}
+
+func Test_jsonReportWriter_WriteConfidenceIntervals(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+	summary := aComparableSummary()
+
+	assert.NoError(t, writeFn(summary, api.BenchmarkSpec{}, api.ReportContext{}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	for _, record := range reportDocument.Records {
+		stats := summary.PerceivedTimeStats(record.Name)
+
+		assertStatEqual(t, record.MeanCIHalfWidth, ConfidenceHalfWidth(stats.MeanConfidenceInterval))
+		assertStatEqual(t, record.MedianCIHalfWidth, ConfidenceHalfWidth(stats.MedianConfidenceInterval))
+	}
+}
+
+func Test_jsonReportWriter_WritePercentiles(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writeFn := NewJSONReportWriter(buffer)
+	summary := aComparableSummary()
+
+	assert.NoError(t, writeFn(summary, api.BenchmarkSpec{Percentiles: []float64{50, 99.9}}, api.ReportContext{}))
+	reportDocument := decodeJSONSummaryReport(t, buffer)
+
+	for _, record := range reportDocument.Records {
+		stats := summary.PerceivedTimeStats(record.Name)
+
+		assert.Equal(t, 2, len(record.Percentiles))
+		assertStatEqual(t, record.Percentiles["p50"], func() (time.Duration, error) { return stats.Percentile(50) })
+		assertStatEqual(t, record.Percentiles["p99.9"], func() (time.Duration, error) { return stats.Percentile(99.9) })
+		assertStatEqual(t, record.P90, func() (time.Duration, error) { return stats.Percentile(90) })
 	}
 }
 
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"io"
+
+	"github.com/sha1n/bert/api"
+)
+
+// mdDiffReportWriter a markdown diff report writer
+type mdDiffReportWriter struct {
+	tableWriter MarkdownTableWriter
+}
+
+// NewMarkdownDiffReportWriter returns a Markdown diff report write handler.
+func NewMarkdownDiffReportWriter(writer io.Writer) WriteDiffReportFn {
+	w := mdDiffReportWriter{
+		tableWriter: NewMarkdownTableWriter(writer),
+	}
+
+	return w.Write
+}
+
+func (rw mdDiffReportWriter) Write(runs []ComparedRun, ctx api.ReportContext) (err error) {
+	if ctx.IncludeHeaders {
+		err = rw.tableWriter.WriteHeaders(DiffReportHeaders)
+	}
+
+	if err == nil {
+		for _, record := range getDiffRecords(runs) {
+			if err = rw.tableWriter.WriteRow(record.toRow()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -35,15 +36,20 @@ func NewMarkdownStreamReportWriter(writer io.Writer, ctx api.ReportContext) RawD
 
 // Handle handles a real time trace event
 func (rw *MarkdownStreamReportWriter) Handle(trace api.Trace) (err error) {
-	_, err = fmt.Fprintf(rw.writer, "| %s | %s | %s | %s | %s | %s | %t |\n",
+	row := []string{
 		FormatDateTime(time.Now(), rw.ctx),
 		trace.ID(),
 		strings.Join(rw.ctx.Labels, ","),
 		FormatReportDuration(func() (time.Duration, error) { return trace.PerceivedTime(), nil }),
 		FormatReportDuration(func() (time.Duration, error) { return trace.UserCPUTime(), nil }),
 		FormatReportDuration(func() (time.Duration, error) { return trace.SystemCPUTime(), nil }),
-		trace.Error() != nil,
-	)
+		fmt.Sprint(trace.Error() != nil),
+		fmt.Sprint(errors.Is(trace.Error(), api.ErrTimeout)),
+		FormatReportDuration(func() (time.Duration, error) { return trace.Wait(), nil }),
+	}
+	row = append(row, FormatReportUsage(func() (api.ResourceUsage, error) { return trace.ResourceUsage(), nil }, FormatReportBytes)...)
+
+	_, err = fmt.Fprintf(rw.writer, "| %s |\n", strings.Join(row, " | "))
 
 	if err == nil {
 		err = rw.writer.Flush()
@@ -57,9 +63,9 @@ func (rw *MarkdownStreamReportWriter) Handle(trace api.Trace) (err error) {
 }
 
 func (rw *MarkdownStreamReportWriter) writeHeader() (err error) {
-	_, err = rw.writer.WriteString("| Timestamp | Scenario | Labels | Duration | User Time | System Time | Error |\n")
+	_, err = rw.writer.WriteString("| Timestamp | Scenario | Labels | Duration | User Time | System Time | Error | Timeout | Wait | Max RSS | Minor Page Faults | Major Page Faults | Voluntary Context Switches | Involuntary Context Switches | Block Input Ops | Block Output Ops |\n")
 	if err == nil {
-		_, err = rw.writer.WriteString("|-----------|----------|--------|----------|-----------|-------------|-------|\n")
+		_, err = rw.writer.WriteString("|-----------|----------|--------|----------|-----------|-------------|-------|---------|------|---------|-------------------|-------------------|----------------------------|------------------------------|-----------------|------------------|\n")
 	}
 
 	return err
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -21,7 +22,10 @@ func TestHandleMd(t *testing.T) {
 	// Headers
 	assert.Equal(
 		t,
-		[]string{"Timestamp", "Scenario", "Labels", "Duration", "User Time", "System Time", "Error"},
+		[]string{
+			"Timestamp", "Scenario", "Labels", "Duration", "User Time", "System Time", "Error", "Timeout", "Wait",
+			"Max RSS", "Minor Page Faults", "Major Page Faults", "Voluntary Context Switches", "Involuntary Context Switches", "Block Input Ops", "Block Output Ops",
+		},
 		allRecords[0],
 	)
 
@@ -40,6 +44,16 @@ func TestHandleMdWithoutHeaders(t *testing.T) {
 	assertMdTraceRecord(t, t2, allRecords[1])
 }
 
+func TestHandleMdWithWait(t *testing.T) {
+	trace := NewFakeTraceWithWait("waiting", time.Second, 2*time.Second)
+
+	allRecords := writeMdRawReport(t, false, trace)
+
+	assert.Equal(t, 1, len(allRecords))
+	assert.Equal(t, "2.0s", allRecords[0][8])
+	assertMdTraceRecord(t, trace, allRecords[0])
+}
+
 func writeMdRawReport(t *testing.T, includeHeaders bool, traces ...api.Trace) [][]string {
 	expectedRows := len(traces)
 	if includeHeaders {
@@ -82,4 +96,7 @@ func assertMdTraceRecord(t *testing.T, trace api.Trace, actualRecord []string) {
 	assert.Equal(t, FormatReportDuration(func() (time.Duration, error) { return trace.UserCPUTime(), nil }), actualRecord[4])
 	assert.Equal(t, FormatReportDuration(func() (time.Duration, error) { return trace.SystemCPUTime(), nil }), actualRecord[5])
 	assert.Equal(t, fmt.Sprint(trace.Error() != nil), actualRecord[6])
+	assert.Equal(t, fmt.Sprint(errors.Is(trace.Error(), api.ErrTimeout)), actualRecord[7])
+	assert.Equal(t, FormatReportDuration(func() (time.Duration, error) { return trace.Wait(), nil }), actualRecord[8])
+	assertUsageRecord(t, trace.ResourceUsage(), FormatReportBytes, actualRecord[9:])
 }
@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/thresholds"
 )
 
+// thresholdFailureMarker marks summary values that failed a threshold check
+const thresholdFailureMarker = " ❌"
+
 // mdReportWriter a simple human readable test report writer
 type mdReportWriter struct {
 	tableWriter MarkdownTableWriter
@@ -24,8 +28,10 @@ func NewMarkdownSummaryReportWriter(writer io.Writer) api.WriteSummaryReportFn {
 }
 
 func (rw mdReportWriter) Write(summary api.Summary, spec api.BenchmarkSpec, ctx api.ReportContext) (err error) {
+	checks := thresholds.Evaluate(spec, summary, ctx.BaselineSummary)
+	percentiles := checkedPercentiles(spec, checks)
 	if ctx.IncludeHeaders {
-		err = rw.tableWriter.WriteHeaders(SummaryReportHeaders)
+		err = rw.tableWriter.WriteHeaders(SummaryReportHeaders(percentiles))
 	}
 
 	if err == nil {
@@ -36,23 +42,178 @@ func (rw mdReportWriter) Write(summary api.Summary, spec api.BenchmarkSpec, ctx
 			userStats := summary.UserTimeStats(id)
 			systemStats := summary.SystemTimeStats(id)
 
-			err = rw.tableWriter.WriteRow([]string{
+			row := []string{
 				timeStr,
 				id,
 				fmt.Sprint(stats.Count()),
 				strings.Join(ctx.Labels, ","),
 				FormatReportDuration(stats.Min),
-				FormatReportDuration(stats.Max),
-				FormatReportDuration(stats.Mean),
-				FormatReportDuration(stats.Median),
-				FormatReportDuration(func() (time.Duration, error) { return stats.Percentile(90) }),
+				markFailed(FormatReportDuration(stats.Max), checks.Failed(id, thresholds.MetricMax)),
+				markFailed(FormatReportDurationWithCI(stats.Mean, stats.MeanConfidenceInterval), checks.Failed(id, thresholds.MetricMean)),
+				markFailed(FormatReportDurationWithCI(stats.Median, stats.MedianConfidenceInterval), checks.Failed(id, thresholds.MetricMedian)),
+			}
+			for _, p := range percentiles {
+				row = append(row, markFailed(FormatReportDuration(percentileOf(stats, p)), p == 90 && checks.Failed(id, thresholds.MetricP90)))
+			}
+			row = append(row,
 				FormatReportDuration(stats.StdDev),
 				FormatReportDuration(userStats.Mean),
 				FormatReportDuration(systemStats.Mean),
-				FormatReportFloatAsRateInPercents(stats.ErrorRate),
-			})
+				markFailed(FormatReportFloatAsRateInPercents(stats.ErrorRate), checks.Failed(id, thresholds.MetricErrorRate)),
+				FormatReportFloatAsRateInPercents(stats.TimeoutRate),
+			)
+			row = append(row, FormatReportUsage(summary.ResourceUsageStats(id).Mean, FormatReportBytes)...)
+
+			err = rw.tableWriter.WriteRow(row)
+		}
+
+	}
+
+	if err == nil {
+		err = rw.writeParameters(summary, GetParameterGroups(spec), ctx)
+	}
+
+	if err == nil {
+		err = rw.writeComparisons(summary, ctx)
+	}
+
+	if err == nil {
+		err = rw.writeThresholds(checks, ctx)
+	}
+
+	if err == nil {
+		err = rw.writeRunMetadata(spec, ctx)
+	}
+
+	return err
+}
+
+func (rw mdReportWriter) writeRunMetadata(spec api.BenchmarkSpec, ctx api.ReportContext) (err error) {
+	properties := GetExecutionOrderProperties(spec)
+	if !ctx.Filter.IsEmpty() {
+		properties = append(properties, RunMetadataProperty{Name: "filter", Value: ctx.Filter.String()})
+	}
+	properties = append(properties, GetRunMetadataProperties(ctx.Metadata, ctx)...)
+	if len(properties) == 0 {
+		return nil
+	}
+
+	if err = rw.tableWriter.writeString("\r\n"); err != nil {
+		return err
+	}
+	if ctx.IncludeHeaders {
+		if err = rw.tableWriter.WriteHeaders(RunMetadataReportHeaders); err != nil {
+			return err
+		}
+	}
+
+	for _, property := range properties {
+		if err = rw.tableWriter.WriteRow([]string{property.Name, property.Value}); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (rw mdReportWriter) writeThresholds(checks thresholds.Checks, ctx api.ReportContext) (err error) {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	if err = rw.tableWriter.writeString("\r\n"); err != nil {
+		return err
+	}
+	if ctx.IncludeHeaders {
+		if err = rw.tableWriter.WriteHeaders(ThresholdsReportHeaders); err != nil {
+			return err
+		}
+	}
+
+	for _, check := range checks {
+		if err = rw.tableWriter.WriteRow([]string{
+			check.ID,
+			check.Metric,
+			FormatThresholdActual(check),
+			FormatThresholdLimit(check),
+			markFailed(FormatThresholdResult(check), !check.Passed()),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (rw mdReportWriter) writeParameters(summary api.Summary, groups []ParameterGroup, ctx api.ReportContext) (err error) {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	if err = rw.tableWriter.writeString("\r\n"); err != nil {
+		return err
+	}
+	if ctx.IncludeHeaders {
+		if err = rw.tableWriter.WriteHeaders(ParametersReportHeaders); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range groups {
+		for _, value := range group.Values {
+			if err = rw.tableWriter.WriteRow([]string{
+				group.Name,
+				value.Value,
+				fmt.Sprint(len(value.IDs)),
+				FormatReportDuration(func() (time.Duration, error) { return value.Mean(summary) }),
+				FormatReportRatio(func() (float64, error) { return value.Ratio(summary, group.Values[0]) }),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+func markFailed(value string, failed bool) string {
+	if failed {
+		return value + thresholdFailureMarker
+	}
+
+	return value
+}
+
+func (rw mdReportWriter) writeComparisons(summary api.Summary, ctx api.ReportContext) (err error) {
+	comparedIds := GetSortedComparedScenarioIds(summary, ctx.Baseline)
+	if len(comparedIds) == 0 {
+		return nil
+	}
+
+	if err = rw.tableWriter.writeString("\r\n"); err != nil {
+		return err
+	}
+	if ctx.IncludeHeaders {
+		if err = rw.tableWriter.WriteHeaders(ComparisonReportHeaders); err != nil {
+			return err
 		}
+	}
 
+	for _, id := range comparedIds {
+		comparison := NewPerceivedTimeComparison(summary, ctx.Baseline, id)
+		lower, upper := FormatReportRatioConfidenceInterval(comparison)
+
+		if err = rw.tableWriter.WriteRow([]string{
+			id,
+			ctx.Baseline,
+			FormatReportRatio(comparison.Ratio),
+			lower,
+			upper,
+			FormatReportPValue(comparison.PValue),
+			DescribeComparison(comparison),
+		}); err != nil {
+			return err
+		}
 	}
 
 	return err
@@ -14,11 +14,11 @@ import (
 func TestCreateMarkdownTableFromWithHeaders(t *testing.T) {
 	includeHeaders := true
 	lines, summary := generateTestMdReport(t, includeHeaders)
-	expectedCellsPerRow := len(SummaryReportHeaders)
+	expectedCellsPerRow := len(SummaryReportHeaders(api.DefaultPercentiles))
 
 	// Verify table structure and dimensions
 	assert.Equal(t, 2 /*header + sep*/ +2 /*data*/ +1 /*CRLF*/, len(lines))
-	assert.Equal(t, "|Timestamp|Scenario|Samples|Labels|Min|Max|Mean|Median|Percentile 90|StdDev|User Time|System Time|Errors|", lines[0])
+	assert.Equal(t, "|Timestamp|Scenario|Samples|Labels|Min|Max|Mean|Median|Percentile 90|StdDev|User Time|System Time|Errors|Timeouts|Max RSS|Minor Page Faults|Major Page Faults|Voluntary Context Switches|Involuntary Context Switches|Block Input Ops|Block Output Ops|", lines[0])
 	assert.Equal(t, expectedCellsPerRow, strings.Count(lines[1], "|----"))
 	assert.Equal(t, expectedCellsPerRow+1, strings.Count(lines[2], "|"))
 	assert.Equal(t, expectedCellsPerRow+1, strings.Count(lines[3], "|"))
@@ -33,7 +33,7 @@ func TestCreateMarkdownTableFromWithHeaders(t *testing.T) {
 func TestCreateMarkdownTableFromWithNoHeaders(t *testing.T) {
 	includeHeaders := false
 	lines, summary := generateTestMdReport(t, includeHeaders)
-	expectedCellsPerRow := len(SummaryReportHeaders)
+	expectedCellsPerRow := len(SummaryReportHeaders(api.DefaultPercentiles))
 
 	// Verify table structure and dimensions
 	assert.Equal(t, 2 /*data*/ +1 /*CRLF*/, len(lines))
@@ -47,6 +47,85 @@ func TestCreateMarkdownTableFromWithNoHeaders(t *testing.T) {
 
 }
 
+func TestCreateMarkdownTableWithPercentiles(t *testing.T) {
+	buf := new(bytes.Buffer)
+	spec := aTwoScenarioSpec()
+	spec.Percentiles = []float64{50, 99.9}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), spec, api.ReportContext{IncludeHeaders: true}))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Contains(t, lines[0], "|Median|Percentile 50|Percentile 99.9|StdDev|")
+	assert.Equal(t, len(SummaryReportHeaders(spec.Percentiles))+1, strings.Count(lines[2], "|"))
+}
+
+func TestCreateMarkdownComparisonTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{IncludeHeaders: true, Baseline: "fast"}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Equal(t, 2 /*header + sep*/ +2 /*data*/ +1 /*empty*/ +2 /*header + sep*/ +1 /*data*/ +1 /*CRLF*/, len(lines))
+	assert.Equal(t, "", lines[4])
+	assert.Equal(t, "|Scenario|Baseline|Ratio|CI Lower|CI Upper|P-Value|Change|", lines[5])
+	assert.True(t, strings.HasPrefix(lines[7], "|slow|fast|2.000x|"))
+	assert.True(t, strings.HasSuffix(lines[7], "|2.00x slower|"))
+}
+
+func TestCreateMarkdownThresholdsTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{IncludeHeaders: true}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aComparableSpecWithThresholds(), ctx))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Equal(t, 2 /*header + sep*/ +2 /*data*/ +1 /*empty*/ +2 /*header + sep*/ +2 /*data*/ +1 /*CRLF*/, len(lines))
+	assert.Contains(t, lines[2], "|fast|")
+	assert.NotContains(t, lines[2], "❌")
+	assert.Contains(t, lines[3], "|slow|")
+	assert.Contains(t, lines[3], "ns ❌|")
+	assert.Equal(t, "|Scenario|Metric|Actual|Limit|Result|", lines[5])
+	assert.Equal(t, "|fast|mean|105ns|150ns|passed|", lines[7])
+	assert.Equal(t, "|slow|mean|211ns|150ns|failed ❌|", lines[8])
+}
+
+func TestCreateMarkdownRunMetadataTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{IncludeHeaders: true, UTCDate: true, Metadata: aRunMetadata()}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Equal(t, "", lines[4])
+	assert.Equal(t, "|Property|Value|", lines[5])
+	assert.Equal(t, "|started|2026-01-02T03:04:05Z|", lines[7])
+	assert.Contains(t, lines, "|host|bench-1|")
+	assert.Contains(t, lines, `|flags|--executions=10 --only=a --only="b (x=1, y=2)"|`)
+}
+
+func TestCreateMarkdownRunMetadataTableWithRandomOrder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	spec := aTwoScenarioSpec()
+	spec.Order = api.OrderShuffle
+	spec.Seed = 1234
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), spec, api.ReportContext{IncludeHeaders: true}))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Contains(t, lines, "|order|shuffle|")
+	assert.Contains(t, lines, "|seed|1234|")
+}
+
+func TestCreateMarkdownRunMetadataTableWithScenarioFilter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{Filter: api.ScenarioFilter{Only: []string{"a", "b*"}, Skip: []string{"c"}}}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	assert.Contains(t, strings.Split(buf.String(), "\r\n"), "|filter|only=a,b* skip=c|")
+}
+
 func generateTestMdReport(t *testing.T, includeHeaders bool) ([]string, api.Summary) {
 	buf := new(bytes.Buffer)
 	writer := buf
@@ -90,3 +169,38 @@ func aTwoScenarioSpec() api.BenchmarkSpec {
 		},
 	}
 }
+
+func TestCreateMarkdownSummaryWithAssertedP90AndCustomPercentiles(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{IncludeHeaders: true}
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aComparableSpecWithP90Threshold(), ctx))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Contains(t, lines[0], "|Percentile 50|Percentile 90|Percentile 99|")
+	assert.Contains(t, lines[3], "|slow|")
+	assert.Contains(t, lines[3], "ns ❌|")
+}
+
+// aComparableSpecWithP90Threshold returns a spec whose 'slow' scenario fails its p90 threshold, and that doesn't report the p90
+func aComparableSpecWithP90Threshold() api.BenchmarkSpec {
+	spec := aComparableSpecWithThresholds()
+	spec.Percentiles = []float64{50, 99}
+	for i := range spec.Scenarios {
+		spec.Scenarios[i].Thresholds = &api.ThresholdsSpec{P90: api.Duration(150)}
+	}
+
+	return spec
+}
+
+func aComparableSpecWithThresholds() api.BenchmarkSpec {
+	thresholds := &api.ThresholdsSpec{Mean: api.Duration(150)}
+
+	return api.BenchmarkSpec{
+		Executions: 10,
+		Scenarios: []api.ScenarioSpec{
+			{Name: "fast", Command: &api.CommandSpec{Cmd: []string{"cmd"}}, Thresholds: thresholds},
+			{Name: "slow", Command: &api.CommandSpec{Cmd: []string{"cmd"}}, Thresholds: thresholds},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sha1n/bert/api"
+)
+
+// RunMetadataProperty a property of run metadata, formatted for report rendering
+type RunMetadataProperty struct {
+	Name  string
+	Value string
+}
+
+// GetRunMetadataProperties returns the properties of the specified run metadata in report order.
+// Properties that are not available are omitted. Returns nil if no metadata is specified.
+func GetRunMetadataProperties(metadata *api.RunMetadata, ctx api.ReportContext) (properties []RunMetadataProperty) {
+	if metadata == nil {
+		return nil
+	}
+
+	add := func(name string, value string) {
+		if value != "" {
+			properties = append(properties, RunMetadataProperty{Name: name, Value: value})
+		}
+	}
+
+	host := metadata.Host
+	add("started", FormatDateTime(metadata.StartTime, ctx))
+	add("ended", FormatDateTime(metadata.EndTime, ctx))
+	add("duration", metadata.Duration.Duration().Round(time.Millisecond).String())
+	add("host", host.Hostname)
+	add("os", joinNonEmpty(host.OS, host.Kernel, host.Arch))
+	if host.CPUs > 0 {
+		add("cpu", joinNonEmpty(host.CPUModel, fmt.Sprintf("x %d", host.CPUs)))
+	}
+	if host.Memory > 0 {
+		add("memory", FormatReportBytes(int64(host.Memory)))
+	}
+	add("load", formatLoadAverage(host.LoadAverage))
+	add("governor", host.CPUGovernor)
+	add("bert", formatVersion(metadata.Version, metadata.Build))
+	add("flags", formatFlags(metadata.Flags))
+	add("stopped", formatStoppedScenarios(metadata.Stopped))
+
+	return properties
+}
+
+// GetExecutionOrderProperties returns the execution order and the seed of the specified benchmark if its order is random,
+// so that the run can be reproduced. Returns nil otherwise.
+func GetExecutionOrderProperties(spec api.BenchmarkSpec) []RunMetadataProperty {
+	order := spec.ExecutionOrder()
+	if !order.IsRandom() {
+		return nil
+	}
+
+	return []RunMetadataProperty{
+		{Name: "order", Value: string(order)},
+		{Name: "seed", Value: fmt.Sprint(spec.Seed)},
+	}
+}
+
+// FormatStoppedExecutions formats the number of executions of a scenario that was stopped by the max time of the run
+func FormatStoppedExecutions(stopped api.StoppedScenario) string {
+	return fmt.Sprintf("%d of %d, max time reached", stopped.Executions, stopped.MaxExecutions)
+}
+
+func formatStoppedScenarios(stopped []api.StoppedScenario) string {
+	formatted := make([]string, len(stopped))
+	for i, scenario := range stopped {
+		formatted[i] = fmt.Sprintf("'%s' (%s)", scenario.ID, FormatStoppedExecutions(scenario))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func joinNonEmpty(values ...string) string {
+	return strings.Join(slices.DeleteFunc(values, func(v string) bool { return v == "" }), " ")
+}
+
+func formatLoadAverage(loadAverage []float64) string {
+	values := make([]string, len(loadAverage))
+	for i, load := range loadAverage {
+		values[i] = fmt.Sprintf("%.2f", load)
+	}
+
+	return strings.Join(values, " ")
+}
+
+func formatVersion(version string, build string) string {
+	if build == "" {
+		return version
+	}
+
+	return fmt.Sprintf("%s (build %s)", version, build)
+}
+
+// formatFlags formats the specified flags as they would be specified on the command line, with a flag per value,
+// so values that contain commas are not ambiguous. Values that contain spaces or quotes are quoted.
+func formatFlags(flags map[string][]string) string {
+	formatted := []string{}
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		for _, value := range flags[name] {
+			if strings.ContainsAny(value, " \t'\"") {
+				value = strconv.Quote(value)
+			}
+			formatted = append(formatted, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRunMetadataProperties(t *testing.T) {
+	properties := GetRunMetadataProperties(aRunMetadata(), api.ReportContext{UTCDate: true})
+
+	assert.Equal(t, []RunMetadataProperty{
+		{"started", "2026-01-02T03:04:05Z"},
+		{"ended", "2026-01-02T03:05:35Z"},
+		{"duration", "1m30s"},
+		{"host", "bench-1"},
+		{"os", "linux 6.1.0 amd64"},
+		{"cpu", "AMD EPYC 7B13 x 8"},
+		{"memory", "16.0GB"},
+		{"load", "0.52 0.40 0.31"},
+		{"governor", "performance"},
+		{"bert", "v1.2.3 (build abc123)"},
+		{"flags", `--executions=10 --only=a --only="b (x=1, y=2)"`},
+	}, properties)
+}
+
+func TestGetRunMetadataPropertiesOmitsUnavailableValues(t *testing.T) {
+	metadata := &api.RunMetadata{Host: api.HostInfo{OS: "windows", Arch: "amd64", CPUs: 4}}
+
+	properties := GetRunMetadataProperties(metadata, api.ReportContext{})
+
+	names := []string{}
+	for _, property := range properties {
+		names = append(names, property.Name)
+	}
+	assert.Equal(t, []string{"started", "ended", "duration", "os", "cpu"}, names)
+	assert.Equal(t, "x 4", properties[4].Value)
+}
+
+func TestGetRunMetadataPropertiesWithStoppedScenarios(t *testing.T) {
+	metadata := aRunMetadata()
+	metadata.Stopped = []api.StoppedScenario{{ID: "a", Executions: 3, MaxExecutions: 5}, {ID: "b", MaxExecutions: 5}}
+
+	properties := GetRunMetadataProperties(metadata, api.ReportContext{})
+
+	assert.Contains(t, properties, RunMetadataProperty{"stopped", "'a' (3 of 5, max time reached), 'b' (0 of 5, max time reached)"})
+}
+
+func TestGetRunMetadataPropertiesWithoutMetadata(t *testing.T) {
+	assert.Nil(t, GetRunMetadataProperties(nil, api.ReportContext{}))
+}
+
+func aRunMetadata() *api.RunMetadata {
+	metadata := &api.RunMetadata{
+		StartTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Host: api.HostInfo{
+			Hostname:    "bench-1",
+			OS:          "linux",
+			Kernel:      "6.1.0",
+			Arch:        "amd64",
+			CPUModel:    "AMD EPYC 7B13",
+			CPUs:        8,
+			Memory:      16 << 30,
+			LoadAverage: []float64{0.52, 0.4, 0.31},
+			CPUGovernor: "performance",
+		},
+		Version: "v1.2.3",
+		Build:   "abc123",
+		Flags:   map[string][]string{"only": {"a", "b (x=1, y=2)"}, "executions": {"10"}},
+	}
+	metadata.End(metadata.StartTime.Add(90 * time.Second))
+
+	return metadata
+}
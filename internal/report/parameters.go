@@ -0,0 +1,91 @@
+package report
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sha1n/bert/api"
+)
+
+// ParameterGroup the scenarios expanded from parameter matrices, grouped by the values of a single parameter
+type ParameterGroup struct {
+	Name   string
+	Values []ParameterValueGroup
+}
+
+// ParameterValueGroup the scenarios that share the same value of a parameter
+type ParameterValueGroup struct {
+	Value string
+	IDs   []api.ID
+}
+
+// GetParameterGroups groups the scenarios of the specified spec by each of their parameters.
+// Parameters are sorted by name and values are kept in the order they first appear in.
+func GetParameterGroups(spec api.BenchmarkSpec) []ParameterGroup {
+	idsByValueByName := map[string]map[string][]api.ID{}
+	valuesByName := map[string][]string{}
+
+	for _, scenario := range spec.Scenarios {
+		for name, value := range scenario.ParameterValues {
+			if idsByValueByName[name] == nil {
+				idsByValueByName[name] = map[string][]api.ID{}
+			}
+			if _, exists := idsByValueByName[name][value]; !exists {
+				valuesByName[name] = append(valuesByName[name], value)
+			}
+			idsByValueByName[name][value] = append(idsByValueByName[name][value], scenario.ID())
+		}
+	}
+
+	groups := []ParameterGroup{}
+	for _, name := range slices.Sorted(maps.Keys(valuesByName)) {
+		group := ParameterGroup{Name: name}
+		for _, value := range valuesByName[name] {
+			group.Values = append(group.Values, ParameterValueGroup{Value: value, IDs: idsByValueByName[name][value]})
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// Mean returns the mean of the perceived time means of the scenarios in this group.
+func (g ParameterValueGroup) Mean(summary api.Summary) (time.Duration, error) {
+	var total time.Duration
+	count := 0
+	for _, id := range g.IDs {
+		stats := summary.PerceivedTimeStats(id)
+		if stats == nil {
+			continue
+		}
+		if mean, err := stats.Mean(); err == nil {
+			total += mean
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, errors.New("no scenario has a mean")
+	}
+
+	return total / time.Duration(count), nil
+}
+
+// Ratio returns the ratio between the mean of this group and the mean of the specified baseline group.
+func (g ParameterValueGroup) Ratio(summary api.Summary, baseline ParameterValueGroup) (float64, error) {
+	mean, err := g.Mean(summary)
+	if err != nil {
+		return 0, err
+	}
+	baselineMean, err := baseline.Mean(summary)
+	if err != nil {
+		return 0, err
+	}
+	if baselineMean == 0 {
+		return 0, errors.New("baseline mean is zero")
+	}
+
+	return float64(mean) / float64(baselineMean), nil
+}
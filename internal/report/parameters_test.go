@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetParameterGroups(t *testing.T) {
+	groups := GetParameterGroups(aParameterizedSpec())
+
+	assert.Equal(
+		t,
+		[]ParameterGroup{
+			{Name: "mode", Values: []ParameterValueGroup{{Value: "a", IDs: []api.ID{"fast", "slow"}}}},
+			{Name: "size", Values: []ParameterValueGroup{{Value: "small", IDs: []api.ID{"fast"}}, {Value: "large", IDs: []api.ID{"slow"}}}},
+		},
+		groups,
+	)
+}
+
+func TestGetParameterGroupsWithoutParameters(t *testing.T) {
+	assert.Empty(t, GetParameterGroups(aTwoScenarioSpec()))
+}
+
+func TestParameterValueGroupMeanAndRatio(t *testing.T) {
+	summary := aComparableSummary()
+	groups := GetParameterGroups(aParameterizedSpec())
+	small, large, both := groups[1].Values[0], groups[1].Values[1], groups[0].Values[0]
+
+	smallMean, _ := summary.PerceivedTimeStats("fast").Mean()
+	largeMean, _ := summary.PerceivedTimeStats("slow").Mean()
+	mean, err := both.Mean(summary)
+	assert.NoError(t, err)
+	assert.Equal(t, (smallMean+largeMean)/2, mean)
+
+	ratio, err := large.Ratio(summary, small)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(largeMean)/float64(smallMean), ratio)
+}
+
+func TestParameterValueGroupMeanWithUnknownScenarios(t *testing.T) {
+	_, err := ParameterValueGroup{Value: "a", IDs: []api.ID{"unknown"}}.Mean(aComparableSummary())
+
+	assert.Error(t, err)
+}
+
+func TestTxtParametersSection(t *testing.T) {
+	text, _ := writeTxtReport(t, aComparableSummary(), aParameterizedSpec(), false)
+
+	assert.Contains(t, text, "PARAMETERS")
+	assert.Contains(t, text, "PARAMETER: size")
+	assert.Regexp(t, `large: \d+ns\s+ratio: 2.010x\s+scenarios: 1`, text)
+
+	text, _ = writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+	assert.NotContains(t, text, "PARAMETERS")
+}
+
+func TestMarkdownParametersTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewMarkdownSummaryReportWriter(buf)(aComparableSummary(), aParameterizedSpec(), api.ReportContext{IncludeHeaders: true}))
+
+	lines := strings.Split(buf.String(), "\r\n")
+	assert.Contains(t, lines, "|Parameter|Value|Scenarios|Mean|Ratio|")
+	assert.Contains(t, lines, "|size|large|1|211ns|2.010x|")
+}
+
+func TestJSONParameters(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewJSONReportWriter(buf)(aComparableSummary(), aParameterizedSpec(), api.ReportContext{}))
+	doc := decodeJSONSummaryReport(t, buf)
+
+	assert.Equal(t, 2, len(doc.Parameters))
+	size := doc.Parameters[1]
+	assert.Equal(t, "size", size.Name)
+	assert.Equal(t, "large", size.Values[1].Value)
+	assert.Equal(t, []string{"slow"}, size.Values[1].Scenarios)
+	assert.Equal(t, int64(211), *size.Values[1].Mean)
+	assert.Equal(t, 1.0, *size.Values[0].Ratio)
+}
+
+func aParameterizedSpec() api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Executions: 10,
+		Scenarios: []api.ScenarioSpec{
+			{Name: "fast", Command: &api.CommandSpec{Cmd: []string{"cmd"}}, ParameterValues: map[string]string{"size": "small", "mode": "a"}},
+			{Name: "slow", Command: &api.CommandSpec{Cmd: []string{"cmd"}}, ParameterValues: map[string]string{"size": "large", "mode": "a"}},
+		},
+	}
+}
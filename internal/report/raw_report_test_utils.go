@@ -2,6 +2,7 @@ package report
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -32,6 +33,21 @@ func assertRawTraceRecord(t *testing.T, trace api.Trace, actualRecord []string)
 	assert.Equal(t, fmt.Sprint(trace.UserCPUTime().Nanoseconds()), actualRecord[4])
 	assert.Equal(t, fmt.Sprint(trace.SystemCPUTime().Nanoseconds()), actualRecord[5])
 	assert.Equal(t, fmt.Sprint(trace.Error() != nil), actualRecord[6])
+	assert.Equal(t, fmt.Sprint(errors.Is(trace.Error(), api.ErrTimeout)), actualRecord[7])
+	assert.Equal(t, fmt.Sprint(trace.Wait().Nanoseconds()), actualRecord[8])
+	assertUsageRecord(t, trace.ResourceUsage(), FormatReportBytesPlain, actualRecord[9:])
+}
+
+func assertUsageRecord(t *testing.T, usage api.ResourceUsage, formatBytes func(int64) string, actualRecord []string) {
+	assert.Equal(t, []string{
+		formatBytes(usage.MaxRSS),
+		fmt.Sprint(usage.MinorPageFaults),
+		fmt.Sprint(usage.MajorPageFaults),
+		fmt.Sprint(usage.VoluntaryContextSwitches),
+		fmt.Sprint(usage.InvoluntaryContextSwitches),
+		fmt.Sprint(usage.BlockInputOps),
+		fmt.Sprint(usage.BlockOutputOps),
+	}, actualRecord)
 }
 
 func writeRawReport(t *testing.T, getHandler GetRawDataHandler, parseRecords ParseRecords, includeHeaders bool, traces ...api.Trace) [][]string {
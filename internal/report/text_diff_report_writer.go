@@ -0,0 +1,49 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sha1n/bert/api"
+)
+
+// textDiffReportWriter a simple human readable diff report writer
+type textDiffReportWriter struct {
+	writer io.Writer
+}
+
+// NewTextDiffReportWriter returns a text diff report write handler.
+func NewTextDiffReportWriter(writer io.Writer) WriteDiffReportFn {
+	w := textDiffReportWriter{
+		writer: writer,
+	}
+
+	return w.Write
+}
+
+func (rw textDiffReportWriter) Write(runs []ComparedRun, ctx api.ReportContext) (err error) {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	if _, err = fmt.Fprintf(rw.writer, "\n%11s\n%11s: %s\n\n", " RESULTS DIFF", "baseline", runs[0].Name); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(rw.writer, 0, 0, 2, ' ', 0)
+	if ctx.IncludeHeaders {
+		if _, err = fmt.Fprintln(tw, strings.Join(DiffReportHeaders, "\t")); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range getDiffRecords(runs) {
+		if _, err = fmt.Fprintln(tw, strings.Join(record.toRow(), "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
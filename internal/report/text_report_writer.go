@@ -2,6 +2,7 @@ package report
 
 import (
 	"bufio"
+	"cmp"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/thresholds"
 )
 
 const attentionIndicatorRune = '•'
@@ -81,12 +83,35 @@ func (trw textReportWriter) Write(summary api.Summary, config api.BenchmarkSpec,
 	trw.writeDate(summary.Time(), ctx)
 	trw.writeTime(summary.Time(), ctx)
 	trw.writeInt64StatLine("scenarios", func() (int64, error) { return int64(len(config.Scenarios)), nil })
-	trw.writeInt64StatLine("executions", func() (int64, error) { return int64(config.Executions), nil })
+	trw.writeExecutions(config)
 	trw.writePropertyLine("alternate", config.Alternate)
+	if order := config.ExecutionOrder(); order.IsRandom() {
+		trw.writePropertyLine("order", order)
+		trw.writePropertyLine("seed", config.Seed)
+	}
+	if !ctx.Filter.IsEmpty() {
+		trw.writePropertyLine("filter", ctx.Filter)
+	}
+	if config.Concurrency > 1 {
+		trw.writePropertyLine("concurrency", config.Concurrency)
+	}
+	if config.MaxTime > 0 {
+		trw.writePropertyLine("max time", config.MaxTime)
+	}
+	if config.MaxLoad > 0 {
+		trw.writePropertyLine("max load", fmt.Sprintf("%.2f (settle %s)", config.MaxLoad, cmp.Or(config.Settle, api.DefaultSettle)))
+	}
+	if ctx.RemoveOutliers {
+		trw.writePropertyLine("outliers", "removed")
+	}
+	if config.SubtractShellOverhead {
+		trw.writePropertyLine("shell overhead", "subtracted")
+	}
 
 	trw.writeSeperator()
 
 	sortedIds := GetSortedScenarioIds(summary)
+	checks := thresholds.Evaluate(config, summary, ctx.BaselineSummary)
 
 	for _, id := range sortedIds {
 		stats := summary.PerceivedTimeStats(id)
@@ -95,28 +120,158 @@ func (trw textReportWriter) Write(summary api.Summary, config api.BenchmarkSpec,
 
 		trw.writeScenarioTitle(id)
 		trw.writeDurationProperty("min", trw.green, stats.Min)
-		trw.writeDurationProperty("mean", trw.cyan, stats.Mean)
-		trw.writeDurationProperty("median", trw.yellow, stats.Median)
+		trw.writeCheckedProperty("mean", trw.cyan, FormatReportDurationWithCI(stats.Mean, stats.MeanConfidenceInterval), checks.Failed(id, thresholds.MetricMean))
+		trw.writeCheckedProperty("median", trw.yellow, FormatReportDurationWithCI(stats.Median, stats.MedianConfidenceInterval), checks.Failed(id, thresholds.MetricMedian))
 		trw.writeNewLine()
 
-		trw.writeDurationProperty("max", trw.magenta, stats.Max)
+		trw.writeCheckedDurationProperty("max", trw.magenta, stats.Max, checks.Failed(id, thresholds.MetricMax))
 		trw.writeDurationProperty("stddev", trw.blue, stats.StdDev)
-		trw.writeDurationProperty("p90", trw.red, func() (time.Duration, error) { return stats.Percentile(90) })
+		trw.writePercentiles(checkedPercentiles(config, checks), stats, checks.Failed(id, thresholds.MetricP90))
 		trw.writeNewLine()
 
 		trw.writeDurationProperty("user", trw.hiblue, userStats.Mean)
 		trw.writeDurationProperty("system", trw.hiblue, sysStats.Mean)
 
-		trw.writeErrorRateStat("errors", stats.ErrorRate)
-
+		trw.writeErrorRateStat("errors", stats.ErrorRate, checks.Failed(id, thresholds.MetricErrorRate))
+		if stats.TimeoutRate() > 0 {
+			trw.writeErrorRateStat("timeouts", stats.TimeoutRate, false)
+		}
 		trw.writeNewLine()
 
+		trw.writeOutliers("outliers", stats)
+		// the number of executions varies between scenarios when they specify their own, or when the time budget is spent
+		if stopped, ok := ctx.Metadata.StoppedScenario(id); ok {
+			trw.writePropertyLine("executions", FormatStoppedExecutions(stopped))
+		} else if config.MaxTime > 0 || hasScenarioExecutions(config) {
+			trw.writePropertyLine("executions", stats.Count())
+		}
+
+		trw.writeResourceUsage(summary.ResourceUsageStats(id))
+
 		trw.writeSeperator()
 	}
+	trw.writeUnstartedScenarios(summary, ctx)
+
+	trw.writeParameters(summary, GetParameterGroups(config))
+	trw.writeComparisons(summary, ctx.Baseline)
+	trw.writeThresholds(checks)
+	trw.writeRunMetadata(ctx)
 
 	return nil
 }
 
+func (trw textReportWriter) writeRunMetadata(ctx api.ReportContext) {
+	properties := GetRunMetadataProperties(ctx.Metadata, ctx)
+	if len(properties) == 0 {
+		return
+	}
+
+	trw.writeTitle(" RUN")
+
+	trw.writeSeperator()
+
+	for _, property := range properties {
+		trw.writePropertyLine(property.Name, property.Value)
+	}
+
+	trw.writeSeperator()
+}
+
+func (trw textReportWriter) writeComparisons(summary api.Summary, baseline api.ID) {
+	comparedIds := GetSortedComparedScenarioIds(summary, baseline)
+	if len(comparedIds) == 0 {
+		return
+	}
+
+	trw.writeTitle(" COMPARISON")
+	trw.writePropertyLine("baseline", trw.yellow.Sprint(baseline))
+
+	trw.writeSeperator()
+
+	for _, id := range comparedIds {
+		comparison := NewPerceivedTimeComparison(summary, baseline, id)
+		lower, upper := FormatReportRatioConfidenceInterval(comparison)
+
+		trw.writeScenarioTitle(id)
+		trw.writeProperty("ratio", FormatReportRatio(comparison.Ratio), trw.cyan)
+		trw.writeProperty(fmt.Sprintf("%d%% CI", int(ComparisonConfidenceLevel*100)), fmt.Sprintf("%s - %s", lower, upper), trw.blue)
+		trw.writeNewLine()
+
+		trw.writeProperty("p-value", FormatReportPValue(comparison.PValue), trw.magenta)
+		trw.writeComparisonDescription("change", comparison)
+		trw.writeNewLine()
+
+		trw.writeSeperator()
+	}
+}
+
+func (trw textReportWriter) writeComparisonDescription(name string, comparison api.Comparison) {
+	var attentionIndicator = ""
+	if ratio, err := comparison.Ratio(); err == nil && ratio > 1 && IsSignificant(comparison) {
+		attentionIndicator = trw.red.Sprintf("%c", attentionIndicatorRune)
+	}
+
+	trw.writeString(fmt.Sprintf("%11s: %s %s", name, DescribeComparison(comparison), attentionIndicator))
+}
+
+func (trw textReportWriter) writeParameters(summary api.Summary, groups []ParameterGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	trw.writeTitle(" PARAMETERS")
+
+	trw.writeSeperator()
+
+	for _, group := range groups {
+		trw.writePropertyLine("PARAMETER", trw.yellow.Sprint(group.Name))
+		for _, value := range group.Values {
+			trw.writeDurationProperty(value.Value, trw.cyan, func() (time.Duration, error) { return value.Mean(summary) })
+			trw.writeProperty("ratio", FormatReportRatio(func() (float64, error) { return value.Ratio(summary, group.Values[0]) }), trw.blue)
+			trw.writeProperty("scenarios", len(value.IDs), trw.magenta)
+			trw.writeNewLine()
+		}
+
+		trw.writeSeperator()
+	}
+}
+
+func (trw textReportWriter) writeThresholds(checks thresholds.Checks) {
+	if len(checks) == 0 {
+		return
+	}
+
+	trw.writeTitle(" THRESHOLDS")
+
+	trw.writeSeperator()
+
+	for i, check := range checks {
+		if i == 0 || check.ID != checks[i-1].ID {
+			trw.writeScenarioTitle(check.ID)
+		}
+
+		trw.writeString(fmt.Sprintf("%11s: %s", check.Metric, trw.describeCheck(check)))
+		trw.writeNewLine()
+
+		if i == len(checks)-1 || check.ID != checks[i+1].ID {
+			trw.writeSeperator()
+		}
+	}
+}
+
+func (trw textReportWriter) describeCheck(check thresholds.Check) string {
+	if check.Passed() {
+		return fmt.Sprintf("%-10s (limit %s) %s", FormatThresholdActual(check), FormatThresholdLimit(check), trw.green.Sprint(ThresholdPassedValue))
+	}
+
+	description := fmt.Sprintf("%-10s (limit %s)", FormatThresholdActual(check), FormatThresholdLimit(check))
+	if check.Err != nil {
+		description = check.Err.Error()
+	}
+
+	return fmt.Sprintf("%s %s %s", description, trw.red.Sprint(ThresholdFailedValue), trw.red.Sprintf("%c", attentionIndicatorRune))
+}
+
 func (trw textReportWriter) writeNewLine() {
 	trw.writeString("\n")
 }
@@ -149,17 +304,108 @@ func (trw textReportWriter) writeDurationProperty(name string, c *color.Color, f
 	trw.writeProperty(name, FormatReportDuration(f), c)
 }
 
-func (trw textReportWriter) writeErrorRateStat(name string, errorRate func() float64) {
+func (trw textReportWriter) writeCheckedDurationProperty(name string, c *color.Color, f func() (time.Duration, error), failed bool) {
+	trw.writeCheckedProperty(name, c, FormatReportDuration(f), failed)
+}
+
+func (trw textReportWriter) writeCheckedProperty(name string, c *color.Color, value string, failed bool) {
+	if !failed {
+		trw.writeProperty(name, value, c)
+		return
+	}
+
+	trw.writeProperty(name, fmt.Sprintf("%s %s", value, trw.red.Sprintf("%c", attentionIndicatorRune)), c)
+}
+
+// writePercentiles writes the specified percentiles, continuing the current line which already has two properties
+func (trw textReportWriter) writePercentiles(percentiles []float64, stats api.Stats, p90Failed bool) {
+	for i, p := range percentiles {
+		if i > 0 && (i+2)%3 == 0 {
+			trw.writeNewLine()
+		}
+		trw.writeCheckedDurationProperty(PercentileName(p), trw.red, percentileOf(stats, p), p == 90 && p90Failed)
+	}
+}
+
+func (trw textReportWriter) writeErrorRateStat(name string, errorRate func() float64, failed bool) {
 	errorRatePercent := int(errorRate() * 100)
 	var attentionIndicator = ""
 
-	if errorRatePercent > 10 {
+	if errorRatePercent > 10 || failed {
 		attentionIndicator = trw.red.Sprintf("%c", attentionIndicatorRune)
 	}
 
 	trw.writeString(fmt.Sprintf("%11s: %d%% %s", name, errorRatePercent, attentionIndicator))
 }
 
+func (trw textReportWriter) writeOutliers(name string, stats api.Stats) {
+	rate := OutlierRate(stats)
+	var warning = ""
+
+	if rate > OutlierWarningRate {
+		warning = fmt.Sprintf("%s high outlier rate, the machine might be noisy", trw.red.Sprintf("%c", attentionIndicatorRune))
+	}
+
+	trw.writeString(fmt.Sprintf("%11s: %d (%d%%) %s", name, stats.Outliers(), int(rate*100), warning))
+	trw.writeNewLine()
+}
+
+func (trw textReportWriter) writeResourceUsage(usageStats api.UsageStats) {
+	usage := FormatReportUsage(usageStats.Mean, FormatReportBytes)
+
+	trw.writeProperty("max rss", usage[0], trw.green)
+	trw.writeProperty("minor pf", usage[1], trw.cyan)
+	trw.writeProperty("major pf", usage[2], trw.yellow)
+	trw.writeNewLine()
+
+	trw.writeProperty("vol csw", usage[3], trw.magenta)
+	trw.writeProperty("invol csw", usage[4], trw.blue)
+	trw.writeProperty("block i/o", fmt.Sprintf("%s/%s", usage[5], usage[6]), trw.hiblue)
+	trw.writeNewLine()
+}
+
+func (trw textReportWriter) writeExecutions(spec api.BenchmarkSpec) {
+	if spec.Adaptive != nil {
+		trw.writePropertyLine("executions", fmt.Sprintf("%d-%d (adaptive)", spec.Adaptive.MinExecutions, spec.Adaptive.MaxExecutions))
+		return
+	}
+	if spec.Executions == 0 {
+		trw.writePropertyLine("executions", "per scenario")
+		return
+	}
+
+	trw.writeInt64StatLine("executions", func() (int64, error) { return int64(spec.Executions), nil })
+}
+
+// writeUnstartedScenarios writes the scenarios that were stopped by the max time of the run before they started,
+// which have no results in the summary.
+func (trw textReportWriter) writeUnstartedScenarios(summary api.Summary, ctx api.ReportContext) {
+	if ctx.Metadata == nil {
+		return
+	}
+
+	for _, stopped := range ctx.Metadata.Stopped {
+		if summary.PerceivedTimeStats(stopped.ID) != nil {
+			continue
+		}
+
+		trw.writeScenarioTitle(stopped.ID)
+		trw.writePropertyLine("executions", FormatStoppedExecutions(stopped))
+		trw.writeSeperator()
+	}
+}
+
+// hasScenarioExecutions returns true if any scenario of the specified benchmark specifies its own number of executions or budget.
+func hasScenarioExecutions(spec api.BenchmarkSpec) bool {
+	for _, scenario := range spec.Scenarios {
+		if scenario.Executions > 0 || scenario.MaxDuration > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (trw textReportWriter) writeInt64StatLine(name string, f func() (int64, error)) {
 	trw.writePropertyLine(name, FormatReportInt64(f))
 }
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -89,6 +90,261 @@ func testTxtSanity(t *testing.T, colorsOn bool) {
 
 }
 
+func TestTxtComparisonSection(t *testing.T) {
+	summary := aComparableSummary()
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(summary, aTwoScenarioSpec(), api.ReportContext{Baseline: "fast"}))
+
+	text := buf.String()
+	assert.Contains(t, text, "COMPARISON")
+	assert.Contains(t, text, "baseline: fast")
+	assert.Contains(t, text, "ratio: 2.000x")
+	assert.Contains(t, text, "change: 2.00x slower •")
+	assert.Equal(t, 1, strings.Count(text, "SCENARIO: fast"), "the baseline scenario is not expected in the comparison section")
+	assert.Equal(t, 2, strings.Count(text, "SCENARIO: slow"))
+}
+
+func TestTxtWithoutBaselineHasNoComparisonSection(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), api.ReportContext{}))
+
+	assert.NotContains(t, buf.String(), "COMPARISON")
+}
+
+func TestTxtThresholdsSection(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aComparableSpecWithThresholds(), api.ReportContext{}))
+
+	text := buf.String()
+	assert.Contains(t, text, "THRESHOLDS")
+	assert.Contains(t, text, "mean: 105ns") // mean of 'fast' is not marked
+	assert.Regexp(t, `mean: 211ns ± \d+ns •`, text)
+	assert.Contains(t, text, "mean: 105ns      (limit 150ns) passed")
+	assert.Contains(t, text, "mean: 211ns      (limit 150ns) failed •")
+}
+
+func TestTxtAssertedP90WithCustomPercentiles(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aComparableSpecWithP90Threshold(), api.ReportContext{}))
+
+	text := buf.String()
+	assert.Contains(t, text, "p50:")
+	assert.Contains(t, text, "p99:")
+	assert.Regexp(t, `p90: 2\d\dns\s+•`, text, "a failed p90 threshold is expected to be marked")
+}
+
+func TestTxtWithoutThresholdsHasNoThresholdsSection(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), api.ReportContext{}))
+
+	assert.NotContains(t, buf.String(), "THRESHOLDS")
+}
+
+func TestTxtOutliers(t *testing.T) {
+	traces := []api.Trace{}
+	for _, d := range []time.Duration{10, 11, 12, 11, 10, 12, 11, 100} {
+		traces = append(traces, NewFakeTrace("noisy", d, 1, 1, nil))
+	}
+	traces = append(traces, NewFakeTrace("quiet", 10, 1, 1, nil), NewFakeTrace("quiet", 11, 1, 1, nil))
+
+	text, lines := writeTxtReport(t, NewFakeSummary(traces...), aTwoScenarioSpec(), false)
+
+	assert.Contains(t, text, "outliers: 1 (12%) • high outlier rate")
+	assert.Contains(t, lines, "outliers: 0 (0%)")
+	assert.NotContains(t, text, "outliers: removed")
+}
+
+func TestTxtWithRemovedOutliers(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), api.ReportContext{RemoveOutliers: true}))
+
+	assert.Contains(t, buf.String(), "outliers: removed")
+}
+
+func TestTxtWithSubtractedShellOverhead(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	text, _ := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.NotContains(t, text, "shell overhead")
+
+	spec.SubtractShellOverhead = true
+	text, _ = writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, text, "shell overhead: subtracted")
+}
+
+func TestTxtTimeouts(t *testing.T) {
+	text, _ := writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+	assert.NotContains(t, text, "timeouts")
+
+	timeoutErr := fmt.Errorf("command %w", api.ErrTimeout)
+	summary := NewFakeSummary(
+		NewFakeTrace("a", time.Second, 1, 1, timeoutErr),
+		NewFakeTrace("a", time.Second, 1, 1, errors.New("failed")),
+		NewFakeTrace("a", time.Second, 1, 1, nil),
+		NewFakeTrace("a", time.Second, 1, 1, nil),
+	)
+	text, _ = writeTxtReport(t, summary, aTwoScenarioSpec(), false)
+	assert.Contains(t, text, "errors: 50%")
+	assert.Contains(t, text, "timeouts: 25%")
+}
+
+func TestTxtResourceUsage(t *testing.T) {
+	summary := NewFakeSummary(
+		NewFakeTraceWithResourceUsage("a", time.Second, api.ResourceUsage{MaxRSS: 1024 * 1024, MinorPageFaults: 10, BlockInputOps: 1, BlockOutputOps: 2}),
+		NewFakeTraceWithResourceUsage("a", time.Second, api.ResourceUsage{MaxRSS: 3 * 1024 * 1024, MinorPageFaults: 20, BlockInputOps: 1, BlockOutputOps: 2}),
+	)
+
+	text, _ := writeTxtReport(t, summary, aTwoScenarioSpec(), false)
+
+	assert.Contains(t, text, "max rss: 2.0MB")
+	assert.Contains(t, text, "minor pf: 15")
+	assert.Contains(t, text, "block i/o: 1/2")
+}
+
+func TestTxtConcurrency(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	text, _ := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.NotContains(t, text, "concurrency")
+
+	spec.Concurrency = 4
+	_, lines := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, lines, "concurrency: 4")
+}
+
+func TestTxtMaxLoad(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	text, _ := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.NotContains(t, text, "max load")
+
+	spec.MaxLoad = 1.5
+	_, lines := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, lines, "max load: 1.50 (settle 1m0s)")
+
+	spec.Settle = api.Duration(30 * time.Second)
+	_, lines = writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, lines, "max load: 1.50 (settle 30s)")
+}
+
+func TestTxtMaxTime(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	spec.Executions = 100
+	text, lines := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.NotContains(t, text, "max time")
+	assert.NotContains(t, lines, "executions: 10", "the number of executions of each scenario is not expected to be reported")
+
+	spec.MaxTime = api.Duration(10 * time.Minute)
+	_, lines = writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, lines, "max time: 10m0s")
+	assert.Contains(t, lines, "executions: 100")
+	assert.Equal(t, 2, countLinesOf(lines, "executions: 10"), "the number of executions of each scenario is expected to be reported")
+}
+
+func TestTxtScenarioExecutions(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	spec.Executions = 0
+	spec.Scenarios[0].Executions = 10
+	spec.Scenarios[1].Executions = 10
+
+	_, lines := writeTxtReport(t, aComparableSummary(), spec, false)
+
+	assert.Contains(t, lines, "executions: per scenario")
+	assert.Equal(t, 2, countLinesOf(lines, "executions: 10"))
+}
+
+func TestTxtRandomOrder(t *testing.T) {
+	spec := aTwoScenarioSpec()
+	text, _ := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.NotContains(t, text, "seed")
+
+	spec.Order = api.OrderShuffle
+	spec.Seed = 1234
+	_, lines := writeTxtReport(t, aComparableSummary(), spec, false)
+	assert.Contains(t, lines, "order: shuffle")
+	assert.Contains(t, lines, "seed: 1234")
+}
+
+func TestTxtScenarioFilter(t *testing.T) {
+	text, _ := writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+	assert.NotContains(t, text, "filter")
+
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{Filter: api.ScenarioFilter{Only: []string{"a", "b*"}, Skip: []string{"c"}}}
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	assert.Contains(t, buf.String(), "filter: only=a,b* skip=c")
+}
+
+func TestTxtRunMetadata(t *testing.T) {
+	text, _ := writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+	assert.NotContains(t, text, "RUN")
+
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{Metadata: aRunMetadata()}
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	text = buf.String()
+	assert.Contains(t, text, "RUN")
+	assert.Contains(t, text, "duration: 1m30s")
+	assert.Contains(t, text, "cpu: AMD EPYC 7B13 x 8")
+	assert.Contains(t, text, "governor: performance")
+}
+
+func TestTxtScenariosStoppedByMaxTime(t *testing.T) {
+	metadata := aRunMetadata()
+	metadata.Stopped = []api.StoppedScenario{
+		{ID: "fast", Executions: 10, MaxExecutions: 20},
+		{ID: "unstarted", Executions: 0, MaxExecutions: 20},
+	}
+
+	buf := new(bytes.Buffer)
+	ctx := api.ReportContext{Metadata: metadata}
+	assert.NoError(t, NewTextReportWriter(buf, false)(aComparableSummary(), aTwoScenarioSpec(), ctx))
+
+	text := buf.String()
+	assert.Contains(t, text, "executions: 10 of 20, max time reached")
+	assert.Contains(t, text, "SCENARIO: unstarted")
+	assert.Contains(t, text, "executions: 0 of 20, max time reached")
+	assert.Contains(t, text, "stopped: 'fast' (10 of 20, max time reached), 'unstarted' (0 of 20, max time reached)")
+}
+
+func TestTxtConfidenceIntervals(t *testing.T) {
+	text, _ := writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+
+	assert.Regexp(t, `mean: 105ns ± \d+ns`, text)
+	assert.Regexp(t, `median: 105ns ± \d+ns`, text)
+}
+
+func TestTxtPercentiles(t *testing.T) {
+	_, lines := writeTxtReport(t, aComparableSummary(), aTwoScenarioSpec(), false)
+	assert.Contains(t, strings.Join(lines, "\n"), "p90:")
+
+	spec := aTwoScenarioSpec()
+	spec.Percentiles = []float64{50, 75, 95, 99, 99.9}
+	text, _ := writeTxtReport(t, aComparableSummary(), spec, false)
+
+	assert.NotContains(t, text, "p90:")
+	for _, name := range []string{"p50:", "p75:", "p95:", "p99:", "p99.9:"} {
+		assert.Contains(t, text, name)
+	}
+}
+
+func aComparableSummary() api.Summary {
+	traces := []api.Trace{}
+	for i := 1; i <= 10; i++ {
+		traces = append(traces,
+			NewFakeTrace("fast", time.Duration(100+i), 1, 1, nil),
+			NewFakeTrace("slow", time.Duration(200+2*i), 1, 1, nil),
+		)
+	}
+
+	return NewFakeSummary(traces...)
+}
+
 func expectedTitleFor(id api.Identifiable) string {
 	return fmt.Sprintf("SCENARIO: %s", id.ID())
 }
@@ -115,3 +371,13 @@ func writeTxtReport(t *testing.T, summary api.Summary, spec api.BenchmarkSpec, c
 
 	return text, lines
 }
+
+func countLinesOf(lines []string, line string) (count int) {
+	for _, l := range lines {
+		if l == line {
+			count++
+		}
+	}
+
+	return count
+}
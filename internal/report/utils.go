@@ -2,15 +2,36 @@ package report
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/thresholds"
 )
 
 // ReportErrorValue ...
 const ReportErrorValue = "ERR"
 
+const (
+	// ThresholdPassedValue ...
+	ThresholdPassedValue = "passed"
+	// ThresholdFailedValue ...
+	ThresholdFailedValue = "failed"
+)
+
+const (
+	// ComparisonConfidenceLevel the confidence level of reported comparison confidence intervals
+	ComparisonConfidenceLevel = 0.95
+	// ComparisonSignificanceLevel the p-value under which a comparison is considered statistically significant
+	ComparisonSignificanceLevel = 0.05
+	// SummaryConfidenceLevel the confidence level of reported mean and median confidence intervals
+	SummaryConfidenceLevel = 0.95
+	// OutlierWarningRate the rate of outliers above which reports warn about noisy measurements
+	OutlierWarningRate = 0.05
+)
+
 var (
 	// RawDataReportHeaders ...
 	RawDataReportHeaders = []string{
@@ -21,10 +42,18 @@ var (
 		"User Time",
 		"System Time",
 		"Error",
+		"Timeout",
+		"Wait",
+		"Max RSS",
+		"Minor Page Faults",
+		"Major Page Faults",
+		"Voluntary Context Switches",
+		"Involuntary Context Switches",
+		"Block Input Ops",
+		"Block Output Ops",
 	}
 
-	// SummaryReportHeaders ...
-	SummaryReportHeaders = []string{
+	summaryReportStatsHeaders = []string{
 		"Timestamp",
 		"Scenario",
 		"Samples",
@@ -33,14 +62,107 @@ var (
 		"Max",
 		"Mean",
 		"Median",
-		"Percentile 90",
+	}
+
+	summaryReportTrailingHeaders = []string{
 		"StdDev",
 		"User Time",
 		"System Time",
 		"Errors",
+		"Timeouts",
+		"Max RSS",
+		"Minor Page Faults",
+		"Major Page Faults",
+		"Voluntary Context Switches",
+		"Involuntary Context Switches",
+		"Block Input Ops",
+		"Block Output Ops",
+	}
+
+	// csvSummaryReportOrderHeaders the headers of the execution order columns of CSV summary reports, which have no
+	// other place for them
+	csvSummaryReportOrderHeaders = []string{
+		"Order",
+		"Seed",
+	}
+
+	// ComparisonReportHeaders ...
+	ComparisonReportHeaders = []string{
+		"Scenario",
+		"Baseline",
+		"Ratio",
+		"CI Lower",
+		"CI Upper",
+		"P-Value",
+		"Change",
+	}
+
+	// ParametersReportHeaders ...
+	ParametersReportHeaders = []string{
+		"Parameter",
+		"Value",
+		"Scenarios",
+		"Mean",
+		"Ratio",
+	}
+
+	// RunMetadataReportHeaders ...
+	RunMetadataReportHeaders = []string{
+		"Property",
+		"Value",
+	}
+
+	// ThresholdsReportHeaders ...
+	ThresholdsReportHeaders = []string{
+		"Scenario",
+		"Metric",
+		"Actual",
+		"Limit",
+		"Result",
 	}
 )
 
+// SummaryReportHeaders returns the summary report headers, with a column for each of the specified percentiles
+func SummaryReportHeaders(percentiles []float64) []string {
+	headers := append([]string{}, summaryReportStatsHeaders...)
+	for _, p := range percentiles {
+		headers = append(headers, fmt.Sprintf("Percentile %s", FormatPercentile(p)))
+	}
+
+	return append(headers, summaryReportTrailingHeaders...)
+}
+
+// FormatPercentile formats a percentile using the minimal number of digits, e.g. '90' or '99.9'
+func FormatPercentile(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// PercentileName returns the short name of a percentile, e.g. 'p90' or 'p99.9'
+func PercentileName(p float64) string {
+	return "p" + FormatPercentile(p)
+}
+
+func percentileOf(stats api.Stats, p float64) func() (time.Duration, error) {
+	return func() (time.Duration, error) { return stats.Percentile(p) }
+}
+
+// checkedPercentiles returns the percentiles to report for the specified benchmark in reports that mark failed thresholds.
+// The 90th percentile is added to the reported percentiles when a threshold asserts it, so that its failures are visible.
+func checkedPercentiles(spec api.BenchmarkSpec, checks thresholds.Checks) []float64 {
+	percentiles := spec.ReportPercentiles()
+	asserted := slices.ContainsFunc(checks, func(check thresholds.Check) bool { return check.Metric == thresholds.MetricP90 })
+	if !asserted || slices.Contains(percentiles, 90) {
+		return percentiles
+	}
+
+	i := slices.IndexFunc(percentiles, func(p float64) bool { return p > 90 })
+	if i < 0 {
+		i = len(percentiles)
+	}
+
+	return slices.Insert(slices.Clone(percentiles), i, 90)
+}
+
 // GetSortedScenarioIds returns a sorted array of scenario IDs for the specified api.Summary
 func GetSortedScenarioIds(summary api.Summary) []api.ID {
 	sortedIDs := summary.IDs()
@@ -49,6 +171,109 @@ func GetSortedScenarioIds(summary api.Summary) []api.ID {
 	return sortedIDs
 }
 
+// GetSortedComparedScenarioIds returns a sorted array of the scenario IDs of the specified api.Summary, excluding the specified baseline.
+// Returns an empty array if the baseline is not part of the summary.
+func GetSortedComparedScenarioIds(summary api.Summary, baseline api.ID) []api.ID {
+	ids := []api.ID{}
+	if baseline == "" || summary.PerceivedTimeStats(baseline) == nil {
+		return ids
+	}
+
+	for _, id := range GetSortedScenarioIds(summary) {
+		if id != baseline {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// NewPerceivedTimeComparison returns a perceived time comparison of the specified scenario against the specified baseline.
+func NewPerceivedTimeComparison(summary api.Summary, baseline api.ID, id api.ID) api.Comparison {
+	return summary.PerceivedTimeComparison(id, summary.PerceivedTimeStats(baseline))
+}
+
+// FormatReportRatio formats a ratio for report rendering
+func FormatReportRatio(f func() (float64, error)) string {
+	value, err := f()
+	if err == nil {
+		return fmt.Sprintf("%.3fx", value)
+	}
+
+	return ReportErrorValue
+}
+
+// FormatReportRatioConfidenceInterval formats the confidence interval of the specified comparison for report rendering
+func FormatReportRatioConfidenceInterval(comparison api.Comparison) (string, string) {
+	lower, upper, err := comparison.RatioConfidenceInterval(ComparisonConfidenceLevel)
+	if err == nil {
+		return fmt.Sprintf("%.3fx", lower), fmt.Sprintf("%.3fx", upper)
+	}
+
+	return ReportErrorValue, ReportErrorValue
+}
+
+// FormatReportPValue formats a p-value for report rendering
+func FormatReportPValue(f func() (float64, error)) string {
+	value, err := f()
+	if err == nil {
+		return fmt.Sprintf("%.4f", value)
+	}
+
+	return ReportErrorValue
+}
+
+// IsSignificant returns true if the difference between the compared samples is statistically significant.
+func IsSignificant(comparison api.Comparison) bool {
+	pValue, err := comparison.PValue()
+
+	return err == nil && pValue < ComparisonSignificanceLevel
+}
+
+// DescribeComparison returns a short human readable description of the specified comparison.
+// Differences that are not statistically significant are described as such.
+func DescribeComparison(comparison api.Comparison) string {
+	ratio, err := comparison.Ratio()
+	if err != nil {
+		return ReportErrorValue
+	}
+	if !IsSignificant(comparison) {
+		return "insignificant"
+	}
+	if ratio > 1 {
+		return fmt.Sprintf("%.2fx slower", ratio)
+	}
+
+	return fmt.Sprintf("%.2fx faster", 1/ratio)
+}
+
+// FormatThresholdActual formats the actual value of the specified threshold check for report rendering
+func FormatThresholdActual(check thresholds.Check) string {
+	if check.Err != nil {
+		return ReportErrorValue
+	}
+
+	return check.FormatActual()
+}
+
+// FormatThresholdLimit formats the limit of the specified threshold check for report rendering
+func FormatThresholdLimit(check thresholds.Check) string {
+	if check.Relative {
+		return fmt.Sprintf("%s vs baseline", check.FormatLimit())
+	}
+
+	return check.FormatLimit()
+}
+
+// FormatThresholdResult formats the result of the specified threshold check for report rendering
+func FormatThresholdResult(check thresholds.Check) string {
+	if check.Passed() {
+		return ThresholdPassedValue
+	}
+
+	return ThresholdFailedValue
+}
+
 // FormatReportDurationPlainNanos formats floats for report rendering with 3 digit precision
 func FormatReportDurationPlainNanos(f func() (time.Duration, error)) string {
 	value, err := f()
@@ -95,6 +320,76 @@ func FormatReportDuration(f func() (time.Duration, error)) string {
 	return ReportErrorValue
 }
 
+// ConfidenceHalfWidth returns a function that calculates the half-width of the specified confidence interval
+// at the summary confidence level.
+func ConfidenceHalfWidth(ci func(level float64) (time.Duration, time.Duration, error)) func() (time.Duration, error) {
+	return func() (time.Duration, error) {
+		lower, upper, err := ci(SummaryConfidenceLevel)
+		return (upper - lower) / 2, err
+	}
+}
+
+// FormatReportDurationWithCI formats a duration followed by the half-width of its confidence interval, e.g. '1.2s ± 10.0ms'.
+// The half-width is omitted if the interval cannot be calculated.
+func FormatReportDurationWithCI(f func() (time.Duration, error), ci func(level float64) (time.Duration, time.Duration, error)) string {
+	value := FormatReportDuration(f)
+	halfWidth, err := ConfidenceHalfWidth(ci)()
+	if err != nil || value == ReportErrorValue {
+		return value
+	}
+
+	return fmt.Sprintf("%s ± %s", value, FormatReportDuration(func() (time.Duration, error) { return halfWidth, nil }))
+}
+
+// FormatReportUsage formats the metrics of the specified resource usage for report rendering, in report header order.
+// The max RSS is formatted using the specified bytes format function.
+func FormatReportUsage(f func() (api.ResourceUsage, error), formatBytes func(int64) string) []string {
+	usage, err := f()
+	if err != nil {
+		return []string{ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue}
+	}
+
+	return []string{
+		formatBytes(usage.MaxRSS),
+		fmt.Sprint(usage.MinorPageFaults),
+		fmt.Sprint(usage.MajorPageFaults),
+		fmt.Sprint(usage.VoluntaryContextSwitches),
+		fmt.Sprint(usage.InvoluntaryContextSwitches),
+		fmt.Sprint(usage.BlockInputOps),
+		fmt.Sprint(usage.BlockOutputOps),
+	}
+}
+
+// FormatReportBytesPlain formats a number of bytes as a plain integer for report rendering
+func FormatReportBytesPlain(value int64) string {
+	return fmt.Sprint(value)
+}
+
+// FormatReportBytes formats a number of bytes using binary units with 1 digit precision for report rendering
+func FormatReportBytes(value int64) string {
+	const unit = 1024
+	if value < unit {
+		return fmt.Sprintf("%dB", value)
+	}
+
+	div, exp := int64(unit), 0
+	for n := value / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%cB", float64(value)/float64(div), "KMGT"[exp])
+}
+
+// OutlierRate returns the rate of outliers among the samples of the specified stats
+func OutlierRate(stats api.Stats) float64 {
+	if stats.Count() == 0 {
+		return 0
+	}
+
+	return float64(stats.Outliers()) / float64(stats.Count())
+}
+
 // FormatReportFloatAsRateInPercents formats a float as rate with percent sign, for report rendering
 func FormatReportFloatAsRateInPercents(f func() float64) string {
 	value := f()
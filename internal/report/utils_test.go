@@ -6,10 +6,34 @@ import (
 	"time"
 
 	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
 )
 
 var now = time.Now()
 
+func TestSummaryReportHeaders(t *testing.T) {
+	headers := SummaryReportHeaders([]float64{50, 99.9})
+
+	assert.Equal(t, []string{"Median", "Percentile 50", "Percentile 99.9", "StdDev"}, headers[7:11])
+	assert.Equal(t, len(SummaryReportHeaders(api.DefaultPercentiles))+1, len(headers))
+}
+
+func TestPercentileName(t *testing.T) {
+	assert.Equal(t, "p90", PercentileName(90))
+	assert.Equal(t, "p99.9", PercentileName(99.9))
+}
+
+func TestFormatReportDurationWithCI(t *testing.T) {
+	value := func() (time.Duration, error) { return time.Second, nil }
+	ci := func(level float64) (time.Duration, time.Duration, error) {
+		return time.Second - 10*time.Millisecond, time.Second + 10*time.Millisecond, nil
+	}
+	failingCI := func(level float64) (time.Duration, time.Duration, error) { return 0, 0, errors.New("too few samples") }
+
+	assert.Equal(t, "1.0s ± 10.0ms", FormatReportDurationWithCI(value, ci))
+	assert.Equal(t, "1.0s", FormatReportDurationWithCI(value, failingCI))
+}
+
 func TestFormatReportFloat3(t *testing.T) {
 	tests := []struct {
 		name string
@@ -46,6 +70,42 @@ func TestFormatReportInt64(t *testing.T) {
 	}
 }
 
+func TestFormatReportBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		want  string
+	}{
+		{name: "bytes", value: 1023, want: "1023B"},
+		{name: "kilobytes", value: 1536, want: "1.5KB"},
+		{name: "megabytes", value: 12 * 1024 * 1024, want: "12.0MB"},
+		{name: "gigabytes", value: 3 * 1024 * 1024 * 1024, want: "3.0GB"},
+		{name: "terabytes", value: 2048 * 1024 * 1024 * 1024 * 1024, want: "2048.0TB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatReportBytes(tt.value))
+		})
+	}
+}
+
+func TestFormatReportUsage(t *testing.T) {
+	usage := api.ResourceUsage{MaxRSS: 2048, MinorPageFaults: 1, MajorPageFaults: 2, VoluntaryContextSwitches: 3, InvoluntaryContextSwitches: 4, BlockInputOps: 5, BlockOutputOps: 6}
+
+	assert.Equal(t,
+		[]string{"2.0KB", "1", "2", "3", "4", "5", "6"},
+		FormatReportUsage(func() (api.ResourceUsage, error) { return usage, nil }, FormatReportBytes),
+	)
+	assert.Equal(t,
+		[]string{"2048", "1", "2", "3", "4", "5", "6"},
+		FormatReportUsage(func() (api.ResourceUsage, error) { return usage, nil }, FormatReportBytesPlain),
+	)
+	assert.Equal(t,
+		[]string{ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue, ReportErrorValue},
+		FormatReportUsage(func() (api.ResourceUsage, error) { return api.ResourceUsage{}, errors.New("") }, FormatReportBytes),
+	)
+}
+
 func TestFormatReportNanosInSecPrecision3(t *testing.T) {
 	tests := []struct {
 		name string
@@ -2,113 +2,436 @@ package exec
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/sha1n/bert/api"
 )
 
 // Execute executes a benchmark and returns an object that provides access to collected stats.
-func Execute(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext) {
+// Returns an ExecutionError if any command failures were reported according to the error policies of the benchmark.
+func Execute(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext) error {
 	execCtx.OnBenchmarkStart()
 	defer execCtx.OnBenchmarkEnd()
 
-	if spec.Alternate {
-		executeAlternately(ctx, spec, execCtx)
-	} else {
-		executeSequentially(ctx, spec, execCtx)
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+	errs := newErrorHandler(ctx, abort, spec, execCtx.Listener)
+	deadline := deadlineOf(spec)
+
+	execCtx = withVariables(spec, execCtx)
+	if spec.Timeout > 0 {
+		execCtx = withDefaultTimeout(spec.Timeout, execCtx)
+	}
+	if spec.SubtractShellOverhead {
+		execCtx = withShellOverheadSubtraction(ctx, spec, execCtx)
+	}
+
+	if spec.HasRoundHooks() && !spec.ExecutionOrder().HasRounds() {
+		slog.Warn(fmt.Sprintf("The '%s' order doesn't execute scenarios in rounds. 'beforeRound' and 'afterRound' are ignored", spec.ExecutionOrder()))
 	}
+
+	executeBenchmarkHook(ctx, "setup", spec.Setup, execCtx, errs)
+
+	progressByScenario := newProgressByScenario(spec, deadline)
+	switch spec.ExecutionOrder() {
+	case api.OrderAlternate:
+		executeAlternately(ctx, spec, progressByScenario, execCtx, errs)
+	case api.OrderRandom:
+		executeInRandomRounds(ctx, spec, progressByScenario, execCtx, errs)
+	case api.OrderShuffle:
+		executeShuffled(ctx, spec, progressByScenario, execCtx, errs)
+	default:
+		executeSequentially(ctx, spec, progressByScenario, execCtx, errs)
+	}
+	finishScenarios(ctx, spec, progressByScenario, execCtx, errs)
+
+	// the teardown is executed even if the benchmark has been aborted, so whatever the setup started is cleaned up
+	teardownCtx, stop := newTeardownContext(ctx)
+	defer stop()
+	executeBenchmarkHook(teardownCtx, "teardown", spec.Teardown, execCtx, errs)
+
+	return errs.err()
+}
+
+// newTeardownContext returns a context for the teardown of a benchmark that has the values of the specified context,
+// but isn't cancelled with it. It is cancelled by an interrupt that is received after it is created instead, so an
+// interrupted benchmark can still be stopped by a second interrupt, e.g. if its teardown hangs.
+func newTeardownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt, syscall.SIGTERM)
+}
+
+// deadlineOf returns the time after which no more executions of the specified benchmark are started, or the zero time
+// if the benchmark has no time budget.
+func deadlineOf(spec api.BenchmarkSpec) time.Time {
+	if spec.MaxTime <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(spec.MaxTime.Duration())
 }
 
-func executeAlternately(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext) {
-	for i := 1; i <= spec.Executions; i++ {
-		for si := range spec.Scenarios {
-			if ctx.Err() != nil {
-				return
+// finishScenarios records scenarios that were stopped by the time budget of the benchmark in the run metadata, and
+// tears down those of them that have been started, but haven't been torn down after their last execution.
+func finishScenarios(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	for _, progress := range progressByScenario {
+		if !progress.stoppedByDeadline() {
+			continue
+		}
+
+		scenario := progress.scenario
+		executions := progress.expectedExecutions()
+		slog.Warn(fmt.Sprintf("The max time of %s has been spent. Scenario '%s' stopped after %d of %d executions", spec.MaxTime, scenario.ID(), executions, progress.maxExecutions))
+		if execCtx.Metadata != nil {
+			execCtx.Metadata.Stopped = append(execCtx.Metadata.Stopped, api.StoppedScenario{ID: scenario.ID(), Executions: executions, MaxExecutions: progress.maxExecutions})
+		}
+		if progress.finish() {
+			scenarioCtx := api.WithRunContext(ctx, progress.runContext(0))
+
+			execCtx.OnExecutionsEstimate(scenario.ID(), executions)
+			executeScenarioTeardown(scenarioCtx, progress, execCtx, errs)
+		}
+	}
+}
+
+func executeAlternately(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	if spec.HasRoundHooks() {
+		executeRounds(ctx, spec, progressByScenario, execCtx, errs, func(n int) []int {
+			order := make([]int, n)
+			for i := range order {
+				order[i] = i
 			}
+			return order
+		})
+		return
+	}
 
-			scenario := spec.Scenarios[si]
+	// round-robin over the scenarios, skipping scenarios that cannot start another execution
+	next := 0
+	schedule := func() (*scenarioProgress, int, bool) {
+		for i := range progressByScenario {
+			progress := progressByScenario[(next+i)%len(progressByScenario)]
+			if execIndex, ok := progress.start(); ok {
+				next = (next + i + 1) % len(progressByScenario)
+				return progress, execIndex, true
+			}
+		}
 
-			execCtx.OnScenarioStart(scenario.ID())
-			if i == 1 {
-				executeScenarioSetup(ctx, scenario, execCtx)
+		return nil, 0, false
+	}
+
+	newWorkerPool(spec.Concurrency).run(ctx, schedule, scenarioRunFn(ctx, spec, execCtx, errs))
+}
+
+// executeInRandomRounds executes the scenarios in rounds of one execution per scenario, each round in a random order.
+func executeInRandomRounds(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	rnd := newRandom(spec.Seed)
+	if spec.HasRoundHooks() {
+		executeRounds(ctx, spec, progressByScenario, execCtx, errs, rnd.Perm)
+		return
+	}
+
+	var round []int
+	next := func() (*scenarioProgress, int, bool) {
+		for len(round) > 0 {
+			progress := progressByScenario[round[0]]
+			round = round[1:]
+			if execIndex, ok := progress.start(); ok {
+				return progress, execIndex, true
 			}
-			executeScenarioCommand(ctx, scenario, i, spec.Executions, execCtx)
-			if i == spec.Executions {
-				executeScenarioTeardown(ctx, scenario, execCtx)
+		}
+
+		return nil, 0, false
+	}
+
+	// scenarios that cannot start another execution are dropped from the current round. once the round is over, a new one begins
+	schedule := func() (*scenarioProgress, int, bool) {
+		if progress, execIndex, ok := next(); ok {
+			return progress, execIndex, true
+		}
+		round = rnd.Perm(len(progressByScenario))
+
+		return next()
+	}
+
+	newWorkerPool(spec.Concurrency).run(ctx, schedule, scenarioRunFn(ctx, spec, execCtx, errs))
+}
+
+// executeRounds executes the scenarios in rounds of one execution per scenario, each round in the order returned by
+// the specified function. Each round is preceded by the 'beforeRound' hook and is followed by the 'afterRound' hook,
+// which is executed once all the executions of the round are done, so rounds never overlap.
+func executeRounds(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler, roundOrder func(n int) []int) {
+	pool := newWorkerPool(spec.Concurrency)
+	run := scenarioRunFn(ctx, spec, execCtx, errs)
+
+	for ctx.Err() == nil && !allDone(progressByScenario) {
+		executeBenchmarkHook(ctx, "beforeRound", spec.BeforeRound, execCtx, errs)
+
+		round := roundOrder(len(progressByScenario))
+		schedule := func() (*scenarioProgress, int, bool) {
+			for len(round) > 0 {
+				progress := progressByScenario[round[0]]
+				round = round[1:]
+				if execIndex, ok := progress.start(); ok {
+					return progress, execIndex, true
+				}
 			}
 
-			execCtx.OnScenarioEnd(scenario.ID())
+			return nil, 0, false
 		}
+		pool.run(ctx, schedule, run)
+
+		executeBenchmarkHook(ctx, "afterRound", spec.AfterRound, execCtx, errs)
 	}
 }
 
-func executeSequentially(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext) {
-	for si := range spec.Scenarios {
-		scenario := spec.Scenarios[si]
+func allDone(progressByScenario []*scenarioProgress) bool {
+	for _, progress := range progressByScenario {
+		if !progress.done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// executeShuffled executes all the executions of all the scenarios in a random order.
+func executeShuffled(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	rnd := newRandom(spec.Seed)
+
+	var queue []int
+	for si, progress := range progressByScenario {
+		for range progress.maxExecutions {
+			queue = append(queue, si)
+		}
+	}
+	rnd.Shuffle(len(queue), func(i, j int) { queue[i], queue[j] = queue[j], queue[i] })
 
-		for i := 1; i <= spec.Executions; i++ {
-			if ctx.Err() != nil {
-				return
+	// executions of scenarios that are done before their max number of executions is reached are dropped from the queue
+	schedule := func() (*scenarioProgress, int, bool) {
+		for len(queue) > 0 {
+			progress := progressByScenario[queue[0]]
+			queue = queue[1:]
+			if execIndex, ok := progress.start(); ok {
+				return progress, execIndex, true
 			}
+		}
+
+		return nil, 0, false
+	}
+
+	newWorkerPool(spec.Concurrency).run(ctx, schedule, scenarioRunFn(ctx, spec, execCtx, errs))
+}
+
+// newProgressByScenario creates the progress of each scenario of the specified benchmark, with the specified deadline.
+func newProgressByScenario(spec api.BenchmarkSpec, deadline time.Time) []*scenarioProgress {
+	progressByScenario := make([]*scenarioProgress, len(spec.Scenarios))
+	for si := range spec.Scenarios {
+		progressByScenario[si] = newScenarioProgress(spec, spec.Scenarios[si])
+		progressByScenario[si].deadline = deadline
+	}
+
+	return progressByScenario
+}
+
+// newRandom returns a deterministic source of randomness for the specified seed.
+func newRandom(seed int64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), 0))
+}
+
+func executeSequentially(ctx context.Context, spec api.BenchmarkSpec, progressByScenario []*scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	for _, progress := range progressByScenario {
+		if ctx.Err() != nil {
+			return
+		}
 
+		schedule := func() (*scenarioProgress, int, bool) {
+			execIndex, ok := progress.start()
+			return progress, execIndex, ok
+		}
+
+		newWorkerPool(spec.Concurrency).run(ctx, schedule, scenarioRunFn(ctx, spec, execCtx, errs))
+	}
+}
+
+// scenarioRunFn returns a function that executes a single run of a scenario. The first run of a scenario is preceded
+// by the scenario setup and warmup and the last run is followed by the scenario teardown.
+// Runs of a scenario that has been skipped due to a failure don't execute any command, except for the teardown, and
+// are not reported to listeners as scenario executions, so they don't affect progress and ETA calculations.
+func scenarioRunFn(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext, errs *errorHandler) runFn {
+	output := newOutputCapture(spec.CaptureOutput)
+	gate := newLoadGate(spec.MaxLoad, spec.Settle.Duration())
+
+	return func(progress *scenarioProgress, execIndex int, concurrency int) {
+		scenario := progress.scenario
+		// commands that are not part of a measured run are identified by run index 0
+		scenarioCtx := api.WithRunContext(ctx, progress.runContext(0))
+
+		progress.setupOnce.Do(func() {
+			executeScenarioSetup(scenarioCtx, progress, execCtx, errs)
+			executeScenarioWarmup(scenarioCtx, progress, spec.WarmupExecutions(scenario), execCtx, errs)
+		})
+
+		startTime := time.Now()
+		var info *api.ExecutionInfo
+		var wait time.Duration
+		measured := !progress.isSkipped()
+		if measured {
 			execCtx.OnScenarioStart(scenario.ID())
-			if i == 1 {
-				executeScenarioSetup(ctx, scenario, execCtx)
-			}
+			runCtx := api.WithRunContext(ctx, progress.runContext(execIndex))
+			info, wait = executeScenarioCommand(runCtx, progress, execIndex, concurrency, execCtx, output, gate, errs)
+		}
+		// time spent waiting for the host to quiet down doesn't count against the time budget of the scenario
+		last := progress.record(info, time.Since(startTime)-wait)
 
-			executeScenarioCommand(ctx, scenario, i, spec.Executions, execCtx)
+		if progress.isEstimated() || progress.isSkipped() {
+			execCtx.OnExecutionsEstimate(scenario.ID(), progress.expectedExecutions())
+		}
+		if last {
+			executeScenarioTeardown(scenarioCtx, progress, execCtx, errs)
+		}
 
-			if i == spec.Executions {
-				executeScenarioTeardown(ctx, scenario, execCtx)
-			}
+		if measured {
 			execCtx.OnScenarioEnd(scenario.ID())
 		}
 	}
 }
 
-func executeScenarioSetup(ctx context.Context, scenario api.ScenarioSpec, execCtx api.ExecutionContext) {
+func executeScenarioSetup(ctx context.Context, progress *scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	scenario := progress.scenario
 	if scenario.BeforeAll != nil {
 		execCtx.OnMessagef(scenario.ID(), "running 'beforeAll' command %v...", scenario.BeforeAll.Cmd)
-		reportIfExecError(execCtx.Executor.ExecuteFn(ctx, scenario.BeforeAll, scenario.WorkingDirectory, scenario.Env), scenario.ID(), execCtx)
+		executeHook(ctx, progress, scenario.BeforeAll, execCtx, errs)
 	}
 }
 
-func executeScenarioTeardown(ctx context.Context, scenario api.ScenarioSpec, execCtx api.ExecutionContext) {
+func executeScenarioTeardown(ctx context.Context, progress *scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	scenario := progress.scenario
 	if scenario.AfterAll != nil {
 		execCtx.OnMessagef(scenario.ID(), "running 'afterAll' command %v...", scenario.AfterAll.Cmd)
-		reportIfExecError(execCtx.Executor.ExecuteFn(ctx, scenario.AfterAll, scenario.WorkingDirectory, scenario.Env), scenario.ID(), execCtx)
+		executeHook(ctx, progress, scenario.AfterAll, execCtx, errs)
 	}
 }
 
-func executeScenarioCommand(ctx context.Context, scenario api.ScenarioSpec, execIndex int, totalExec int, execCtx api.ExecutionContext) {
-	execCtx.OnMessagef(scenario.ID(), "run %d of %d", execIndex, totalExec)
-	if scenario.BeforeEach != nil {
-		execCtx.OnMessagef(scenario.ID(), "running 'beforeEach' command %v", scenario.BeforeEach.Cmd)
-		reportIfExecError(execCtx.Executor.ExecuteFn(ctx, scenario.BeforeEach, scenario.WorkingDirectory, scenario.Env), scenario.ID(), execCtx)
+// executeScenarioWarmup runs the full 'beforeEach', command, 'afterEach' cycle the specified number of times without tracing.
+func executeScenarioWarmup(ctx context.Context, progress *scenarioProgress, warmup int, execCtx api.ExecutionContext, errs *errorHandler) {
+	if warmup < 1 {
+		return
+	}
+
+	scenario := progress.scenario
+	execCtx.OnWarmupStart(scenario.ID())
+	defer execCtx.OnWarmupEnd(scenario.ID())
+
+	for i := 1; i <= warmup; i++ {
+		if ctx.Err() != nil || progress.isSkipped() {
+			return
+		}
+
+		execCtx.OnMessagef(scenario.ID(), "warmup run %d of %d", i, warmup)
+		executeBeforeEach(ctx, progress, execCtx, errs)
+
+		execCtx.OnMessagef(scenario.ID(), "running warmup command %v", scenario.Command.Cmd)
+		_, err := execCtx.Executor.ExecuteFn(withIsolation(ctx, scenario.Isolation), scenario.Command, scenario.WorkingDirectory, scenario.Env)()
+		errs.handle(progress, scenario.Command, err)
+
+		executeAfterEach(ctx, progress, execCtx, errs)
 	}
+}
+
+// executeScenarioCommand executes a measured run of a scenario and returns the execution info of the benchmarked command
+// and the time the run waited for the load of the host to drop before the command started.
+func executeScenarioCommand(ctx context.Context, progress *scenarioProgress, execIndex int, concurrency int, execCtx api.ExecutionContext, output *outputCapture, gate *loadGate, errs *errorHandler) (*api.ExecutionInfo, time.Duration) {
+	scenario := progress.scenario
+	execCtx.OnMessagef(scenario.ID(), "run %d of %d", execIndex, progress.maxExecutions)
+	executeBeforeEach(ctx, progress, execCtx, errs)
+	wait := gate.wait(ctx, scenario.ID(), execCtx.Listener)
 
 	execCtx.OnMessagef(scenario.ID(), "running benchmark command %v", scenario.Command.Cmd)
-	executeFn := execCtx.Executor.ExecuteFn(ctx, scenario.Command, scenario.WorkingDirectory, scenario.Env)
+	commandCtx, endCapture := output.start(withIsolation(ctx, scenario.Isolation), progress.runContext(execIndex))
+	executeFn := execCtx.Executor.ExecuteFn(commandCtx, scenario.Command, scenario.WorkingDirectory, scenario.Env)
 
 	endTrace := execCtx.Tracer.Start(scenario)
 	info, err := executeFn()
-	endTrace(info, err)
+	endTrace(withRunInfo(info, concurrency, wait), err)
+
+	errs.handle(progress, scenario.Command, endCapture(err))
+
+	executeAfterEach(ctx, progress, execCtx, errs)
+
+	return info, wait
+}
 
-	reportIfError(err, scenario.ID(), execCtx)
+// withRunInfo returns a copy of the specified execution info with the specified concurrency level and wait time.
+func withRunInfo(info *api.ExecutionInfo, concurrency int, wait time.Duration) *api.ExecutionInfo {
+	infoCopy := api.ExecutionInfo{}
+	if info != nil {
+		infoCopy = *info
+	}
+	infoCopy.Concurrency = concurrency
+	infoCopy.Wait = wait
+
+	return &infoCopy
+}
+
+func executeBeforeEach(ctx context.Context, progress *scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	scenario := progress.scenario
+	if scenario.BeforeEach != nil {
+		execCtx.OnMessagef(scenario.ID(), "running 'beforeEach' command %v", scenario.BeforeEach.Cmd)
+		executeHook(ctx, progress, scenario.BeforeEach, execCtx, errs)
+	}
+}
 
+func executeAfterEach(ctx context.Context, progress *scenarioProgress, execCtx api.ExecutionContext, errs *errorHandler) {
+	scenario := progress.scenario
 	if scenario.AfterEach != nil {
 		execCtx.OnMessagef(scenario.ID(), "running 'afterEach' command %v", scenario.AfterEach.Cmd)
-		reportIfExecError(execCtx.Executor.ExecuteFn(ctx, scenario.AfterEach, scenario.WorkingDirectory, scenario.Env), scenario.ID(), execCtx)
+		executeHook(ctx, progress, scenario.AfterEach, execCtx, errs)
 	}
 }
 
-func reportIfError(err error, id api.ID, ctx api.ExecutionContext) {
-	if err != nil {
-		ctx.OnError(id, err)
+// executeHook executes the specified hook command, retries it as many times as it specifies if it fails,
+// and handles its last failure according to its error policy.
+func executeHook(ctx context.Context, progress *scenarioProgress, cmd *api.CommandSpec, execCtx api.ExecutionContext, errs *errorHandler) {
+	err := executeWithRetries(ctx, cmd, progress.scenario.WorkingDirectory, progress.scenario.Env, execCtx, func(attempt int, err error) {
+		execCtx.OnMessagef(progress.scenario.ID(), "command %v failed, retrying (%d of %d)... %s", cmd.Cmd, attempt, cmd.Retries, err)
+	})
+
+	errs.handle(progress, cmd, err)
+}
+
+// executeBenchmarkHook executes the specified benchmark level hook command, if specified, in the same way as executeHook.
+// Benchmark level hooks don't belong to any scenario, so they are reported to the log rather than to the listener.
+func executeBenchmarkHook(ctx context.Context, name string, cmd *api.CommandSpec, execCtx api.ExecutionContext, errs *errorHandler) {
+	if cmd == nil || ctx.Err() != nil {
+		return
 	}
+
+	slog.Info(fmt.Sprintf("Running '%s' command %v...", name, cmd.Cmd))
+	err := executeWithRetries(ctx, cmd, "", nil, execCtx, func(attempt int, err error) {
+		slog.Warn(fmt.Sprintf("The '%s' command %v failed, retrying (%d of %d)... %s", name, cmd.Cmd, attempt, cmd.Retries, err))
+	})
+
+	errs.handleBenchmarkHook(name, cmd, err)
 }
 
-func reportIfExecError(exec api.ExecCommandFn, id api.ID, ctx api.ExecutionContext) {
-	if _, err := exec(); err != nil {
-		ctx.OnError(id, err)
+// executeWithRetries executes the specified command and retries it as many times as it specifies if it fails.
+// Returns the error of the last attempt.
+func executeWithRetries(ctx context.Context, cmd *api.CommandSpec, workingDir string, env map[string]string, execCtx api.ExecutionContext, onRetry func(attempt int, err error)) error {
+	_, err := execCtx.Executor.ExecuteFn(ctx, cmd, workingDir, env)()
+	for attempt := 1; err != nil && attempt <= cmd.Retries && ctx.Err() == nil; attempt++ {
+		onRetry(attempt, err)
+		_, err = execCtx.Executor.ExecuteFn(ctx, cmd, workingDir, env)()
 	}
+
+	return err
 }
@@ -2,7 +2,11 @@ package exec
 
 import (
 	"context"
+	"maps"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/sha1n/bert/api"
 	"github.com/sha1n/bert/pkg/ui"
@@ -15,8 +19,8 @@ func TestExecuteBenchmarkWithMinimalSpec(t *testing.T) {
 	execRecordingMock := executeWith(spec)
 
 	assert.Equal(t, 4 /* 2 executions * 2 specs */, len(execRecordingMock.RecordedCommandSeq))
-	assertFirstScenarioCommand := assertRecordedCommandWith(t, spec.Scenarios[0])
-	assertSecondScenarioCommand := assertRecordedCommandWith(t, spec.Scenarios[1])
+	assertFirstScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
+	assertSecondScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[1])
 
 	assertFirstScenarioCommand(spec.Scenarios[0].Command, execRecordingMock.RecordedCommandSeq[0])
 	assertFirstScenarioCommand(spec.Scenarios[0].Command, execRecordingMock.RecordedCommandSeq[1])
@@ -30,8 +34,8 @@ func TestExecuteBenchmarkWithMinimalAlternateSpec(t *testing.T) {
 	execRecordingMock := executeWith(spec)
 
 	assert.Equal(t, 4 /* 2 executions * 2 specs */, len(execRecordingMock.RecordedCommandSeq))
-	assertFirstScenarioCommand := assertRecordedCommandWith(t, spec.Scenarios[0])
-	assertSecondScenarioCommand := assertRecordedCommandWith(t, spec.Scenarios[1])
+	assertFirstScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
+	assertSecondScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[1])
 
 	assertFirstScenarioCommand(spec.Scenarios[0].Command, execRecordingMock.RecordedCommandSeq[0])
 	assertSecondScenarioCommand(spec.Scenarios[1].Command, execRecordingMock.RecordedCommandSeq[1])
@@ -39,6 +43,53 @@ func TestExecuteBenchmarkWithMinimalAlternateSpec(t *testing.T) {
 	assertSecondScenarioCommand(spec.Scenarios[1].Command, execRecordingMock.RecordedCommandSeq[3])
 }
 
+func TestExecuteBenchmarkInRandomRounds(t *testing.T) {
+	spec := aBasicSpecWith(false, 10)
+	spec.Order = api.OrderRandom
+	spec.Seed = 42
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, 20, len(executed))
+	for round := 0; round < 10; round++ {
+		assert.ElementsMatch(t, []string{"a", "b"}, executed[round*2:round*2+2], "each round is expected to execute every scenario once")
+	}
+	assert.Equal(t, executed, executedScenariosOf(executeWith(spec)), "the same seed is expected to produce the same order")
+
+	spec.Seed = 7
+	assert.NotEqual(t, executed, executedScenariosOf(executeWith(spec)))
+}
+
+func TestExecuteShuffledBenchmark(t *testing.T) {
+	spec := aBasicSpecWith(false, 10)
+	spec.Order = api.OrderShuffle
+	spec.Seed = 42
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, 20, len(executed))
+	assert.Equal(t, 10, countOf(executed, "a"))
+	assert.Equal(t, 10, countOf(executed, "b"))
+	assert.Equal(t, executed, executedScenariosOf(executeWith(spec)), "the same seed is expected to produce the same order")
+
+	spec.Seed = 7
+	assert.NotEqual(t, executed, executedScenariosOf(executeWith(spec)))
+}
+
+func TestExecuteShuffledBenchmarkWithSkippedScenario(t *testing.T) {
+	spec := aBasicSpecWith(false, 10)
+	spec.Order = api.OrderShuffle
+	spec.Seed = 42
+	spec.Scenarios[0].Command.OnError = api.ErrorPolicySkipScenario
+	executor := newFailingExecutor("cmd a")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{"scenario A"}}, err)
+	assert.Equal(t, 1, executor.executionsOf("cmd a"))
+	assert.Equal(t, 10, executor.executionsOf("cmd b"))
+}
+
 func TestExecuteBenchmarkWithSetupAndTeardownSpecs(t *testing.T) {
 	spec := aSpecWithSetupAndTeardownCommands(2)
 
@@ -46,7 +97,7 @@ func TestExecuteBenchmarkWithSetupAndTeardownSpecs(t *testing.T) {
 
 	assert.Equal(t, 8, len(execRecordingMock.RecordedCommandSeq))
 
-	assertScenarioCommand := assertRecordedCommandWith(t, spec.Scenarios[0])
+	assertScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
 
 	assertScenarioCommand(spec.Scenarios[0].BeforeAll, execRecordingMock.RecordedCommandSeq[0])
 
@@ -63,6 +114,236 @@ func TestExecuteBenchmarkWithSetupAndTeardownSpecs(t *testing.T) {
 	assertScenarioCommand(spec.Scenarios[0].AfterAll, execRecordingMock.RecordedCommandSeq[7])
 }
 
+func TestExecuteBenchmarkWithWarmup(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(1)
+	spec.Warmup = 1
+	tracer := NewTracer(100)
+	execCtx := api.NewExecutionContext(tracer, &CmdRecordingExecutor{}, ui.NewLoggingProgressListener())
+
+	Execute(context.Background(), spec, execCtx)
+
+	execRecordingMock := execCtx.Executor.(*CmdRecordingExecutor)
+	assert.Equal(t, 8, len(execRecordingMock.RecordedCommandSeq))
+	assert.Equal(t, 1, len(tracer.Stream()), "warmup executions are not expected to be traced")
+
+	assertScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
+
+	assertScenarioCommand(spec.Scenarios[0].BeforeAll, execRecordingMock.RecordedCommandSeq[0])
+
+	// Warmup
+	assertScenarioCommand(spec.Scenarios[0].BeforeEach, execRecordingMock.RecordedCommandSeq[1])
+	assertScenarioCommand(spec.Scenarios[0].Command, execRecordingMock.RecordedCommandSeq[2])
+	assertScenarioCommand(spec.Scenarios[0].AfterEach, execRecordingMock.RecordedCommandSeq[3])
+
+	// Execution #1
+	assertScenarioCommand(spec.Scenarios[0].BeforeEach, execRecordingMock.RecordedCommandSeq[4])
+	assertScenarioCommand(spec.Scenarios[0].Command, execRecordingMock.RecordedCommandSeq[5])
+	assertScenarioCommand(spec.Scenarios[0].AfterEach, execRecordingMock.RecordedCommandSeq[6])
+
+	assertScenarioCommand(spec.Scenarios[0].AfterAll, execRecordingMock.RecordedCommandSeq[7])
+}
+
+func TestExecuteBenchmarkWithScenarioWarmupOverride(t *testing.T) {
+	spec := aBasicSpecWith(true, 1)
+	spec.Warmup = 2
+	warmup := 1
+	spec.Scenarios[1].Warmup = &warmup
+
+	execRecordingMock := executeWith(spec)
+
+	assert.Equal(t, 5 /* (2 warmup + 1) + (1 warmup + 1) */, len(execRecordingMock.RecordedCommandSeq))
+}
+
+func TestExecuteBenchmarkWithZeroScenarioWarmupOverride(t *testing.T) {
+	spec := aBasicSpecWith(true, 1)
+	spec.Warmup = 2
+	noWarmup := 0
+	spec.Scenarios[1].Warmup = &noWarmup
+
+	execRecordingMock := executeWith(spec)
+
+	assert.Equal(t, 4 /* (2 warmup + 1) + (0 warmup + 1) */, len(execRecordingMock.RecordedCommandSeq))
+}
+
+func TestExecuteAdaptiveBenchmarkStopsWhenTargetIsReached(t *testing.T) {
+	for _, alternate := range []bool{false, true} {
+		spec := aBasicSpecWith(alternate, 0)
+		spec.Adaptive = &api.AdaptiveSpec{MinExecutions: 3, MaxExecutions: 10, TargetRSE: 1}
+
+		execRecordingMock := executeWith(spec)
+
+		// the recording executor reports identical durations, so the target is reached immediately
+		assert.Equal(t, 6 /* 3 min executions * 2 specs */, len(execRecordingMock.RecordedCommandSeq))
+	}
+}
+
+func TestExecuteAdaptiveBenchmarkWithoutTargetRunsMaxExecutions(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(0)
+	spec.Adaptive = &api.AdaptiveSpec{MinExecutions: 2, MaxExecutions: 4}
+
+	execRecordingMock := executeWith(spec)
+
+	assert.Equal(t, 1+4*3+1, len(execRecordingMock.RecordedCommandSeq))
+
+	assertScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
+	assertScenarioCommand(spec.Scenarios[0].BeforeAll, execRecordingMock.RecordedCommandSeq[0])
+	assertScenarioCommand(spec.Scenarios[0].AfterAll, execRecordingMock.RecordedCommandSeq[13])
+}
+
+func TestExecuteConcurrentBenchmark(t *testing.T) {
+	for _, alternate := range []bool{false, true} {
+		spec := aSpecWithSetupAndTeardownCommands(6)
+		spec.Alternate = alternate
+		spec.Concurrency = 3
+		tracer := NewTracer(100)
+		execCtx := api.NewExecutionContext(tracer, &CmdRecordingExecutor{}, ui.NewLoggingProgressListener())
+
+		Execute(context.Background(), spec, execCtx)
+
+		execRecordingMock := execCtx.Executor.(*CmdRecordingExecutor)
+		assert.Equal(t, 1+6*3+1, len(execRecordingMock.RecordedCommandSeq))
+
+		assertScenarioCommand := assertRecordedCommandWith(t, spec, spec.Scenarios[0])
+		assertScenarioCommand(spec.Scenarios[0].BeforeAll, execRecordingMock.RecordedCommandSeq[0])
+		assertScenarioCommand(spec.Scenarios[0].AfterAll, execRecordingMock.RecordedCommandSeq[19])
+
+		assert.Equal(t, 6, len(tracer.Stream()))
+		for i := 0; i < 6; i++ {
+			trace := <-tracer.Stream()
+			assert.True(t, trace.Concurrency() >= 1 && trace.Concurrency() <= spec.Concurrency)
+		}
+	}
+}
+
+func TestExecuteSequentialBenchmarkRecordsConcurrencyLevelOne(t *testing.T) {
+	spec := aBasicSpecWith(false, 2)
+	tracer := NewTracer(100)
+	execCtx := api.NewExecutionContext(tracer, &CmdRecordingExecutor{}, ui.NewLoggingProgressListener())
+
+	Execute(context.Background(), spec, execCtx)
+
+	assert.Equal(t, 4, len(tracer.Stream()))
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, 1, (<-tracer.Stream()).Concurrency())
+	}
+}
+
+func TestExecuteBenchmarkSetupAndTeardown(t *testing.T) {
+	spec := aBasicSpecWith(false, 2)
+	spec.Setup = &api.CommandSpec{Cmd: []string{"setup"}}
+	spec.Teardown = &api.CommandSpec{Cmd: []string{"teardown"}}
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, []string{"setup", "a", "a", "b", "b", "teardown"}, executed)
+}
+
+func TestExecuteAlternatelyWithRoundHooks(t *testing.T) {
+	spec := aBasicSpecWith(true, 2)
+	spec.BeforeRound = &api.CommandSpec{Cmd: []string{"before"}}
+	spec.AfterRound = &api.CommandSpec{Cmd: []string{"after"}}
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, []string{"before", "a", "b", "after", "before", "a", "b", "after"}, executed)
+}
+
+func TestExecuteRandomRoundsWithRoundHooks(t *testing.T) {
+	spec := aBasicSpecWith(false, 3)
+	spec.Order = api.OrderRandom
+	spec.Concurrency = 2
+	spec.BeforeRound = &api.CommandSpec{Cmd: []string{"before"}}
+	spec.AfterRound = &api.CommandSpec{Cmd: []string{"after"}}
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, 3*4, len(executed))
+	for i := 0; i < len(executed); i += 4 {
+		assert.Equal(t, "before", executed[i])
+		assert.ElementsMatch(t, []string{"a", "b"}, executed[i+1:i+3], "each round is expected to execute every scenario once")
+		assert.Equal(t, "after", executed[i+3])
+	}
+}
+
+func TestExecuteRoundHooksWithAdaptiveExecution(t *testing.T) {
+	spec := aBasicSpecWith(true, 0)
+	spec.Adaptive = &api.AdaptiveSpec{MinExecutions: 2, MaxExecutions: 3}
+	spec.BeforeRound = &api.CommandSpec{Cmd: []string{"before"}}
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, 3, countOf(executed, "before"))
+	assert.Equal(t, 3, countOf(executed, "a"))
+	assert.Equal(t, 3, countOf(executed, "b"))
+}
+
+func TestExecuteSequentiallyIgnoresRoundHooks(t *testing.T) {
+	spec := aBasicSpecWith(false, 2)
+	spec.BeforeRound = &api.CommandSpec{Cmd: []string{"before"}}
+	spec.AfterRound = &api.CommandSpec{Cmd: []string{"after"}}
+
+	executed := executedScenariosOf(executeWith(spec))
+
+	assert.Equal(t, []string{"a", "a", "b", "b"}, executed)
+}
+
+func TestExecuteBenchmarkWithScenarioExecutions(t *testing.T) {
+	for _, order := range []api.ExecutionOrder{api.OrderSequential, api.OrderAlternate, api.OrderRandom, api.OrderShuffle} {
+		spec := aBasicSpecWith(false, 0)
+		spec.Order = order
+		spec.Scenarios[0].Executions = 1
+		spec.Scenarios[1].Executions = 3
+
+		executed := executedScenariosOf(executeWith(spec))
+
+		assert.Equal(t, 1, countOf(executed, "a"), order)
+		assert.Equal(t, 3, countOf(executed, "b"), order)
+	}
+}
+
+func TestExecuteBenchmarkStopsSchedulingWhenMaxTimeIsSpent(t *testing.T) {
+	for _, alternate := range []bool{false, true} {
+		spec := aBasicSpecWith(alternate, 1000)
+		spec.MaxTime = api.Duration(50 * time.Millisecond)
+		spec.Scenarios[0].AfterAll = &api.CommandSpec{Cmd: []string{"teardown a"}}
+		spec.Scenarios[1].AfterAll = &api.CommandSpec{Cmd: []string{"teardown b"}}
+		executor := &sleepingExecutor{delay: 5 * time.Millisecond}
+		execCtx := api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener())
+		execCtx.Metadata = &api.RunMetadata{}
+
+		assert.NoError(t, Execute(context.Background(), spec, execCtx))
+
+		executed := executedScenariosOf(&executor.CmdRecordingExecutor)
+		assert.Less(t, countOf(executed, "a")+countOf(executed, "b"), 100)
+		assert.Equal(t, []api.StoppedScenario{
+			{ID: "scenario A", Executions: countOf(executed, "a"), MaxExecutions: 1000},
+			{ID: "scenario B", Executions: countOf(executed, "b"), MaxExecutions: 1000},
+		}, execCtx.Metadata.Stopped, "both scenarios are expected to be recorded as stopped")
+		assert.Equal(t, 1, countOf(executed, "teardown a"), "started scenarios are expected to be torn down")
+		if alternate {
+			assert.Equal(t, 1, countOf(executed, "teardown b"), "started scenarios are expected to be torn down")
+		} else {
+			assert.Zero(t, countOf(executed, "b"), "scenarios that haven't started are not expected to run")
+			assert.Zero(t, countOf(executed, "teardown b"))
+		}
+	}
+}
+
+// sleepingExecutor records executions like CmdRecordingExecutor, and takes the specified time to execute each command
+type sleepingExecutor struct {
+	CmdRecordingExecutor
+	delay time.Duration
+}
+
+func (e *sleepingExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	execFn := e.CmdRecordingExecutor.ExecuteFn(ctx, cmd, defaultWorkingDir, env)
+
+	return func() (*api.ExecutionInfo, error) {
+		time.Sleep(e.delay)
+		return execFn()
+	}
+}
+
 func executeWith(spec api.BenchmarkSpec) *CmdRecordingExecutor {
 	recordingCtx := recordingExecutionContext()
 
@@ -71,11 +352,54 @@ func executeWith(spec api.BenchmarkSpec) *CmdRecordingExecutor {
 	return recordingCtx.Executor.(*CmdRecordingExecutor)
 }
 
-func assertRecordedCommandWith(t *testing.T, scenario api.ScenarioSpec) func(expected *api.CommandSpec, actual *RecordedExecutionParams) {
+// executedScenariosOf returns the last argument of each recorded command, in execution order
+func executedScenariosOf(executor *CmdRecordingExecutor) (executed []string) {
+	for _, params := range executor.RecordedCommandSeq {
+		executed = append(executed, params.Spec.Cmd[len(params.Spec.Cmd)-1])
+	}
+
+	return executed
+}
+
+func countOf(values []string, value string) (count int) {
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+
+	return count
+}
+
+func assertRecordedCommandWith(t *testing.T, spec api.BenchmarkSpec, scenario api.ScenarioSpec) func(expected *api.CommandSpec, actual *RecordedExecutionParams) {
+	outputDir, err := filepath.Abs(spec.OutputDir)
+	assert.NoError(t, err)
+	total := spec.MaxExecutionsOf(scenario)
+
 	return func(expected *api.CommandSpec, actual *RecordedExecutionParams) {
 		assert.Equal(t, expected, actual.Spec)
 		assert.Equal(t, scenario.WorkingDirectory, actual.DefaultWorkingDir)
-		assert.Equal(t, scenario.Env, actual.Env)
+
+		// 'beforeAll' and 'afterAll' commands run outside of measured runs, other commands run as part of warmup runs
+		// (index 0) or measured runs
+		expectedRunIndex := "0"
+		if expected != scenario.BeforeAll && expected != scenario.AfterAll {
+			runIndex, err := strconv.Atoi(actual.Env[VarRunIndex])
+			assert.NoError(t, err)
+			assert.True(t, runIndex >= 0 && runIndex <= total, "unexpected run index %d", runIndex)
+			expectedRunIndex = actual.Env[VarRunIndex]
+		}
+
+		expectedEnv := maps.Clone(scenario.Env)
+		if expectedEnv == nil {
+			expectedEnv = map[string]string{}
+		}
+		expectedEnv[VarScenario] = scenario.ID()
+		expectedEnv[VarRunIndex] = expectedRunIndex
+		expectedEnv[VarRunTotal] = strconv.Itoa(total)
+		expectedEnv[VarOutputDir] = outputDir
+
+		assert.Equal(t, expectedEnv, actual.Env)
 	}
 }
 
@@ -0,0 +1,55 @@
+//go:build unix
+
+package exec
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBenchmarkTeardownIsStoppedByAnInterrupt(t *testing.T) {
+	spec := aBasicSpecWith(false, 1)
+	spec.Teardown = &api.CommandSpec{Cmd: []string{"teardown"}}
+	executor := &blockingTeardownExecutor{started: make(chan struct{})}
+	execCtx := recordingExecutionContext()
+	execCtx.Executor = executor
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // the benchmark has already been interrupted
+
+	done := make(chan error)
+	go func() { done <- Execute(ctx, spec, execCtx) }()
+
+	<-executor.started
+	assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGINT))
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "the teardown is expected to be stopped by a second interrupt")
+	}
+}
+
+// blockingTeardownExecutor executes teardown commands until their context is cancelled
+type blockingTeardownExecutor struct {
+	CmdRecordingExecutor
+	started chan struct{}
+}
+
+func (e *blockingTeardownExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	if cmd.Cmd[0] != "teardown" {
+		return e.CmdRecordingExecutor.ExecuteFn(ctx, cmd, defaultWorkingDir, env)
+	}
+
+	return func() (*api.ExecutionInfo, error) {
+		close(e.started)
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,48 @@
+package exec
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"github.com/montanaflynn/stats"
+)
+
+const (
+	// bootstrapResamples the number of resamples used to estimate the distribution of a statistic
+	bootstrapResamples = 1000
+	// bootstrapSeed seeds resampling, so that the same samples always produce the same intervals
+	bootstrapSeed = 1
+)
+
+// bootstrapConfidenceInterval estimates the confidence interval of the specified statistic at the specified level (e.g. 0.95),
+// using the percentile bootstrap method.
+func bootstrapConfidenceInterval(samples stats.Float64Data, statistic func(stats.Float64Data) (float64, error), level float64) (lower float64, upper float64, err error) {
+	if level <= 0 || level >= 1 {
+		return 0, 0, errors.New("confidence level must be between 0 and 1")
+	}
+	if samples.Len() < 2 {
+		return 0, 0, errors.New("at least two samples are required")
+	}
+
+	rng := rand.New(rand.NewPCG(bootstrapSeed, uint64(samples.Len())))
+	resample := make(stats.Float64Data, samples.Len())
+	estimates := make(stats.Float64Data, bootstrapResamples)
+
+	for i := range estimates {
+		for j := range resample {
+			resample[j] = samples[rng.IntN(samples.Len())]
+		}
+		if estimates[i], err = statistic(resample); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if lower, err = stats.Percentile(estimates, (1-level)/2*100); err != nil {
+		return 0, 0, err
+	}
+	if upper, err = stats.Percentile(estimates, (1+level)/2*100); err != nil {
+		return 0, 0, err
+	}
+
+	return lower, upper, nil
+}
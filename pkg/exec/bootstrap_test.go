@@ -0,0 +1,56 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/montanaflynn/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapConfidenceInterval(t *testing.T) {
+	samples := stats.Float64Data{10, 12, 11, 13, 9, 10, 11, 12, 10, 11, 14, 8}
+	mean, _ := stats.Mean(samples)
+
+	lower, upper, err := bootstrapConfidenceInterval(samples, stats.Mean, 0.95)
+
+	assert.NoError(t, err)
+	assert.Less(t, lower, mean)
+	assert.Greater(t, upper, mean)
+	assert.GreaterOrEqual(t, lower, 8.0)
+	assert.LessOrEqual(t, upper, 14.0)
+}
+
+func TestBootstrapConfidenceIntervalIsReproducible(t *testing.T) {
+	samples := stats.Float64Data{10, 12, 11, 13, 9, 10, 11, 12, 10, 11, 14, 8}
+
+	lower1, upper1, _ := bootstrapConfidenceInterval(samples, stats.Median, 0.95)
+	lower2, upper2, _ := bootstrapConfidenceInterval(samples, stats.Median, 0.95)
+
+	assert.Equal(t, lower1, lower2)
+	assert.Equal(t, upper1, upper2)
+}
+
+func TestBootstrapConfidenceIntervalNarrowsWithLevel(t *testing.T) {
+	samples := stats.Float64Data{10, 12, 11, 13, 9, 10, 11, 12, 10, 11, 14, 8}
+
+	lower95, upper95, _ := bootstrapConfidenceInterval(samples, stats.Mean, 0.95)
+	lower50, upper50, _ := bootstrapConfidenceInterval(samples, stats.Mean, 0.5)
+
+	assert.Less(t, upper50-lower50, upper95-lower95)
+}
+
+func TestBootstrapConfidenceIntervalWithConstantSamples(t *testing.T) {
+	lower, upper, err := bootstrapConfidenceInterval(stats.Float64Data{5, 5, 5}, stats.Mean, 0.95)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, lower)
+	assert.Equal(t, 5.0, upper)
+}
+
+func TestBootstrapConfidenceIntervalWithInvalidInput(t *testing.T) {
+	_, _, err := bootstrapConfidenceInterval(stats.Float64Data{1}, stats.Mean, 0.95)
+	assert.Error(t, err)
+
+	_, _, err = bootstrapConfidenceInterval(stats.Float64Data{1, 2, 3}, stats.Mean, 1)
+	assert.Error(t, err)
+}
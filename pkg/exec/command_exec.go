@@ -2,11 +2,14 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/sha1n/bert/api"
@@ -32,28 +35,83 @@ func NewCommandExecutor(pipeStdout bool, pipeStderr bool, outputWriter io.Writer
 func (ce *commandExecutor) ExecuteFn(ctx context.Context, cmdSpec *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
 	slog.Debug(fmt.Sprintf("Going to execute command %v", cmdSpec.Cmd))
 
-	execCmd := exec.CommandContext(ctx, cmdSpec.Cmd[0], cmdSpec.Cmd[1:]...)
-	ce.configureCommand(cmdSpec, execCmd, defaultWorkingDir, env)
-
 	return func() (execInfo *api.ExecutionInfo, err error) {
+		runCtx, cancel := withTimeout(ctx, cmdSpec.Timeout.Duration())
+		defer cancel()
+
+		execCmd := newCommand(runCtx, cmdSpec)
+		ce.configureCommand(cmdSpec, execCmd, defaultWorkingDir, env)
+		if output, ok := capturedOutputOf(ctx); ok {
+			execCmd.Stdout = teeTo(execCmd.Stdout, output.stdout)
+			execCmd.Stderr = teeTo(execCmd.Stderr, output.stderr)
+		}
+		if cmdSpec.Stdin != "" {
+			var stdin *os.File
+			if stdin, err = openStdin(cmdSpec, execCmd.Dir); err != nil {
+				return nil, err
+			}
+			defer stdin.Close()
+			execCmd.Stdin = stdin
+		}
+		expect := newExpectation(cmdSpec.Expect)
+		if expect != nil {
+			expect.capture(execCmd)
+		}
+
 		startTime := time.Now()
-		err = execCmd.Run()
+		err = runIsolated(execCmd, isolationOf(ctx))
 		perceivedTime := time.Since(startTime)
+		if err != nil && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("command %v %w after %s", cmdSpec.Cmd, api.ErrTimeout, cmdSpec.Timeout)
+		}
+		if expect != nil {
+			err = expect.check(cmdSpec.Cmd, err)
+		}
+
+		// runs that are killed, e.g. by their timeout, are recorded with the time they ran for rather than as zero
 		state := execCmd.ProcessState
-		if state != nil && state.Exited() {
+		if state != nil {
 			execInfo = &api.ExecutionInfo{
 				ExitCode:      state.ExitCode(),
 				UserTime:      state.UserTime(),
 				SystemTime:    state.SystemTime(),
 				PerceivedTime: perceivedTime,
+				ResourceUsage: resourceUsageOf(state),
 			}
-
 		}
 
 		return
 	}
 }
 
+// newCommand creates a command that runs the specified command line arguments directly, or in a shell if one is specified.
+// The command runs in its own process group, which is killed as a whole when the specified context is done.
+func newCommand(ctx context.Context, cmdSpec *api.CommandSpec) (execCmd *exec.Cmd) {
+	if cmdSpec.Shell != "" {
+		execCmd = exec.CommandContext(ctx, string(cmdSpec.Shell), "-c", strings.Join(cmdSpec.Cmd, " "))
+	} else {
+		execCmd = exec.CommandContext(ctx, cmdSpec.Cmd[0], cmdSpec.Cmd[1:]...)
+	}
+	configureProcessGroup(execCmd)
+
+	return execCmd
+}
+
+// openStdin opens the stdin file of the specified command. Relative paths are resolved against the working directory of the command.
+func openStdin(cmdSpec *api.CommandSpec, dir string) (*os.File, error) {
+	path := osutil.ExpandUserPath(cmdSpec.Stdin)
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+
+	stdin, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the stdin file of command %v. %w", cmdSpec.Cmd, err)
+	}
+
+	return stdin, nil
+}
+
 func (ce *commandExecutor) configureCommand(cmd *api.CommandSpec, execCmd *exec.Cmd, defaultWorkingDir string, env map[string]string) {
 	if cmd.WorkingDirectory != "" {
 		slog.Debug(fmt.Sprintf("Setting command working directory to '%s'", cmd.WorkingDirectory))
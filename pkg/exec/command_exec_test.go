@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -130,6 +133,44 @@ func TestExecCommandFnWithExistingCommand(t *testing.T) {
 	assert.GreaterOrEqual(t, execInfo.SystemTime, time.Nanosecond*0)
 }
 
+func TestExecCommandFnReportsResourceUsage(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("resource usage is not supported on " + runtime.GOOS)
+	}
+
+	spec := aCommandSpec([]string{"go", "version"}, "")
+	executor := NewCommandExecutor(false, false, io.Discard).(*commandExecutor)
+
+	execInfo, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Greater(t, execInfo.ResourceUsage.MaxRSS, int64(1024), "max RSS is expected to be reported in bytes")
+	assert.Greater(t, execInfo.ResourceUsage.MinorPageFaults, int64(0))
+}
+
+func TestNewCommandWithShell(t *testing.T) {
+	spec := &api.CommandSpec{Cmd: []string{"ls *.go", "| wc -l"}, Shell: "bash"}
+
+	execCmd := newCommand(context.Background(), spec)
+
+	assert.Equal(t, []string{"bash", "-c", "ls *.go | wc -l"}, execCmd.Args)
+}
+
+func TestExecCommandFnWithShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	buf := new(bytes.Buffer)
+	spec := &api.CommandSpec{Cmd: []string{"echo a b c | wc -w && echo done"}, Shell: api.DefaultShell}
+	executor := NewCommandExecutor(true, false, buf)
+
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "done"}, strings.Fields(buf.String()))
+}
+
 func TestExecCommandFnWithContextCancellation(t *testing.T) {
 	spec := aCommandSpec([]string{"sleep", "10"}, "")
 	executor := NewCommandExecutor(false, false, io.Discard).(*commandExecutor)
@@ -145,6 +186,168 @@ func TestExecCommandFnWithContextCancellation(t *testing.T) {
 	assert.Nil(t, execInfo)
 }
 
+func TestExecCommandFnWithTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sleep' is not expected to be available on " + runtime.GOOS)
+	}
+
+	spec := &api.CommandSpec{Cmd: []string{"sleep", "10"}, Timeout: api.Duration(100 * time.Millisecond)}
+	executor := NewCommandExecutor(false, false, io.Discard)
+
+	startTime := time.Now()
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.ErrorIs(t, err, api.ErrTimeout)
+	assert.Less(t, time.Since(startTime), 5*time.Second)
+}
+
+func TestExecCommandFnWithTimeoutKillsChildProcesses(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are not supported on " + runtime.GOOS)
+	}
+
+	// the background sleep inherits the stdout pipe, so the execution can only end early if it is killed too
+	spec := &api.CommandSpec{Cmd: []string{"sleep 10 & wait"}, Shell: api.DefaultShell, Timeout: api.Duration(100 * time.Millisecond)}
+	executor := NewCommandExecutor(true, false, io.Discard)
+
+	startTime := time.Now()
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.ErrorIs(t, err, api.ErrTimeout)
+	assert.Less(t, time.Since(startTime), 5*time.Second)
+}
+
+func TestExecCommandFnWithinTimeout(t *testing.T) {
+	spec := aCommandSpec([]string{"go", "version"}, "")
+	spec.Timeout = api.Duration(time.Minute)
+	executor := NewCommandExecutor(false, false, io.Discard)
+
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.NoError(t, err)
+}
+
+func TestExecCommandFnWithExpectedExitCodes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	executor := NewCommandExecutor(false, false, io.Discard)
+	execute := func(cmd string, expect *api.ExpectSpec) (*api.ExecutionInfo, error) {
+		spec := &api.CommandSpec{Cmd: []string{cmd}, Shell: api.DefaultShell, Expect: expect}
+		return executor.ExecuteFn(context.Background(), spec, "", nil)()
+	}
+
+	info, err := execute("exit 2", &api.ExpectSpec{ExitCode: []int{0, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, info.ExitCode)
+
+	_, err = execute("exit 1", &api.ExpectSpec{ExitCode: []int{0, 2}})
+	assert.EqualError(t, err, "command [exit 1] exited with code 1, expected one of [0 2]")
+
+	_, err = execute("exit 0", &api.ExpectSpec{ExitCode: []int{1}})
+	assert.Error(t, err)
+
+	_, err = execute("exit 1", &api.ExpectSpec{})
+	assert.Error(t, err, "a zero exit code is expected by default")
+}
+
+func TestExecCommandFnWithExpectedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	executor := NewCommandExecutor(false, false, io.Discard)
+	execute := func(expect *api.ExpectSpec) error {
+		spec := &api.CommandSpec{Cmd: []string{"echo 'build succeeded' && echo 'warning: unused' >&2"}, Shell: api.DefaultShell, Expect: expect}
+		_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+		return err
+	}
+
+	assert.NoError(t, execute(&api.ExpectSpec{StdoutMatches: "succe+ded", StderrMatches: "^warning"}))
+	assert.NoError(t, execute(&api.ExpectSpec{StdoutNotMatches: "error", StderrNotMatches: "error"}))
+	assert.EqualError(t, execute(&api.ExpectSpec{StdoutMatches: "failed"}), "command [echo 'build succeeded' && echo 'warning: unused' >&2] stdout doesn't match 'failed'")
+	assert.EqualError(t, execute(&api.ExpectSpec{StderrNotMatches: "warning"}), "command [echo 'build succeeded' && echo 'warning: unused' >&2] stderr matches 'warning'")
+}
+
+func TestExecCommandFnWithExpectedOutputAndPipedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	buf := new(bytes.Buffer)
+	spec := &api.CommandSpec{Cmd: []string{"echo hello"}, Shell: api.DefaultShell, Expect: &api.ExpectSpec{StdoutMatches: "hello"}}
+	executor := NewCommandExecutor(true, false, buf)
+
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\n", buf.String(), "captured output is expected to be piped too")
+}
+
+func TestExecCommandFnWithExpectationsAndTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sleep' is not expected to be available on " + runtime.GOOS)
+	}
+
+	spec := &api.CommandSpec{Cmd: []string{"sleep", "10"}, Timeout: api.Duration(100 * time.Millisecond), Expect: &api.ExpectSpec{ExitCode: []int{-1, 0, 137}}}
+	executor := NewCommandExecutor(false, false, io.Discard)
+
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.ErrorIs(t, err, api.ErrTimeout, "timeouts are not expected to be affected by expected exit codes")
+}
+
+func TestExecCommandFnWithStdin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'cat' is not expected to be available on " + runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "input.txt"), []byte("input"), 0644))
+	executor := NewCommandExecutor(false, false, io.Discard)
+	stdout := new(bytes.Buffer)
+	ctx := withCapturedOutput(context.Background(), stdout, io.Discard)
+
+	for _, spec := range []*api.CommandSpec{
+		{Cmd: []string{"cat"}, Stdin: filepath.Join(dir, "input.txt")},
+		{Cmd: []string{"cat"}, Stdin: "input.txt", WorkingDirectory: dir},
+	} {
+		stdout.Reset()
+
+		_, err := executor.ExecuteFn(ctx, spec, "", nil)()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "input", stdout.String())
+	}
+}
+
+func TestExecCommandFnWithNonExistingStdin(t *testing.T) {
+	spec := &api.CommandSpec{Cmd: []string{"cat"}, Stdin: filepath.Join(t.TempDir(), "missing.txt")}
+	executor := NewCommandExecutor(false, false, io.Discard)
+
+	_, err := executor.ExecuteFn(context.Background(), spec, "", nil)()
+
+	assert.ErrorContains(t, err, "failed to open the stdin file of command [cat]")
+}
+
+func TestExecCommandFnWithCapturedOutputAndPipedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	piped, stdout, stderr := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	spec := &api.CommandSpec{Cmd: []string{"echo out && echo err >&2"}, Shell: api.DefaultShell}
+	executor := NewCommandExecutor(true, false, piped)
+
+	_, err := executor.ExecuteFn(withCapturedOutput(context.Background(), stdout, stderr), spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "out\n", stdout.String())
+	assert.Equal(t, "err\n", stderr.String())
+	assert.Equal(t, "out\n", piped.String(), "captured output is expected to be piped too")
+}
+
 func configureCommandWithIOSpec(pipeStdout, pipeStderr bool, writer io.Writer) *exec.Cmd {
 	spec := aCommandSpec(aNonExistingCommand(), "")
 	executor := NewCommandExecutor(pipeStdout, pipeStderr, writer).(*commandExecutor)
@@ -0,0 +1,140 @@
+package exec
+
+import (
+	"errors"
+	"math"
+
+	"github.com/montanaflynn/stats"
+	"github.com/sha1n/bert/api"
+)
+
+// NewComparison creates a new Comparison of the specified stats against the specified baseline stats.
+func NewComparison(baseline api.Stats, other api.Stats) api.Comparison {
+	return &_comparison{
+		baselineSamples: toFloat64Data(baseline),
+		otherSamples:    toFloat64Data(other),
+	}
+}
+
+type _comparison struct {
+	baselineSamples stats.Float64Data
+	otherSamples    stats.Float64Data
+}
+
+func (c *_comparison) Ratio() (ratio float64, err error) {
+	var baselineMean, otherMean float64
+	if baselineMean, otherMean, err = c.means(); err == nil {
+		ratio = otherMean / baselineMean
+	}
+
+	return
+}
+
+// RatioConfidenceInterval uses the delta method to approximate the standard error of the log of the ratio
+// between the means, and returns the bounds of the normal confidence interval around it.
+func (c *_comparison) RatioConfidenceInterval(level float64) (lower float64, upper float64, err error) {
+	if level <= 0 || level >= 1 {
+		return 0, 0, errors.New("confidence level must be between 0 and 1")
+	}
+	if c.baselineSamples.Len() < 2 || c.otherSamples.Len() < 2 {
+		return 0, 0, errors.New("at least two samples are required on each side")
+	}
+
+	var baselineMean, otherMean, baselineStdDev, otherStdDev float64
+	if baselineMean, otherMean, err = c.means(); err != nil {
+		return
+	}
+	if baselineStdDev, err = stats.StandardDeviationSample(c.baselineSamples); err != nil {
+		return
+	}
+	if otherStdDev, err = stats.StandardDeviationSample(c.otherSamples); err != nil {
+		return
+	}
+
+	logRatio := math.Log(otherMean / baselineMean)
+	stdErr := math.Sqrt(
+		math.Pow(baselineStdDev/baselineMean, 2)/float64(c.baselineSamples.Len()) +
+			math.Pow(otherStdDev/otherMean, 2)/float64(c.otherSamples.Len()),
+	)
+	z := stats.NormPpf(1-(1-level)/2, 0, 1)
+
+	return math.Exp(logRatio - z*stdErr), math.Exp(logRatio + z*stdErr), nil
+}
+
+// PValue calculates the p-value of a two-sided Mann-Whitney U test, using the normal approximation
+// with tie and continuity corrections.
+func (c *_comparison) PValue() (float64, error) {
+	n1, n2 := float64(c.otherSamples.Len()), float64(c.baselineSamples.Len())
+	if n1 == 0 || n2 == 0 {
+		return 0, stats.ErrEmptyInput
+	}
+
+	all := append(append(stats.Float64Data{}, c.otherSamples...), c.baselineSamples...)
+	ranks, err := stats.Rank(all)
+	if err != nil {
+		return 0, err
+	}
+
+	rankSum := 0.0
+	for i := 0; i < c.otherSamples.Len(); i++ {
+		rankSum += ranks[i]
+	}
+
+	n := n1 + n2
+	u := rankSum - n1*(n1+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - tiesCorrection(ranks)/(n*(n-1))))
+	if sigma == 0 {
+		return 1, nil
+	}
+
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+
+	return math.Min(1, 2*stats.NormSf(z, 0, 1)), nil
+}
+
+func (c *_comparison) means() (baselineMean float64, otherMean float64, err error) {
+	if baselineMean, err = stats.Mean(c.baselineSamples); err != nil {
+		return
+	}
+	if otherMean, err = stats.Mean(c.otherSamples); err != nil {
+		return
+	}
+	if baselineMean == 0 {
+		err = errors.New("baseline mean is zero")
+	}
+
+	return
+}
+
+// tiesCorrection returns the sum of t^3 - t over all groups of tied ranks, where t is the size of the group.
+func tiesCorrection(ranks []float64) float64 {
+	counts := map[float64]float64{}
+	for _, r := range ranks {
+		counts[r]++
+	}
+
+	correction := 0.0
+	for _, t := range counts {
+		correction += t*t*t - t
+	}
+
+	return correction
+}
+
+func toFloat64Data(s api.Stats) stats.Float64Data {
+	if s == nil {
+		return stats.Float64Data{}
+	}
+
+	samples := s.Samples()
+	data := make(stats.Float64Data, len(samples))
+	for i, sample := range samples {
+		data[i] = float64(sample.Nanoseconds())
+	}
+
+	return data
+}
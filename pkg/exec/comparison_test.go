@@ -0,0 +1,101 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparisonRatio(t *testing.T) {
+	baseline := aStatsWith(10, 10, 10, 10)
+	other := aStatsWith(20, 20, 20, 20)
+
+	ratio, err := NewComparison(baseline, other).Ratio()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, ratio)
+}
+
+func TestComparisonRatioWithZeroBaselineMean(t *testing.T) {
+	_, err := NewComparison(aStatsWith(0, 0), aStatsWith(1, 2)).Ratio()
+
+	assert.Error(t, err)
+}
+
+func TestComparisonRatioWithMissingBaseline(t *testing.T) {
+	_, err := NewComparison(nil, aStatsWith(1, 2)).Ratio()
+
+	assert.Error(t, err)
+}
+
+func TestSummaryPerceivedTimeComparison(t *testing.T) {
+	summary := NewSummary(map[api.ID][]api.Trace{
+		"baseline": tracesWith("baseline", 10, 10, 10, 10),
+		"other":    tracesWith("other", 20, 20, 20, 20),
+	})
+
+	ratio, err := summary.PerceivedTimeComparison("other", summary.PerceivedTimeStats("baseline")).Ratio()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, ratio)
+}
+
+func TestComparisonRatioConfidenceInterval(t *testing.T) {
+	comparison := NewComparison(aStatsWith(9, 10, 11, 10, 9, 11), aStatsWith(19, 20, 21, 20, 19, 21))
+
+	lower, upper, err := comparison.RatioConfidenceInterval(0.95)
+
+	assert.NoError(t, err)
+	assert.Less(t, lower, 2.0)
+	assert.Greater(t, upper, 2.0)
+	assert.Greater(t, lower, 1.0)
+}
+
+func TestComparisonRatioConfidenceIntervalWithInsufficientSamples(t *testing.T) {
+	_, _, err := NewComparison(aStatsWith(1), aStatsWith(1, 2)).RatioConfidenceInterval(0.95)
+
+	assert.Error(t, err)
+}
+
+func TestComparisonRatioConfidenceIntervalWithInvalidLevel(t *testing.T) {
+	_, _, err := NewComparison(aStatsWith(1, 2), aStatsWith(1, 2)).RatioConfidenceInterval(1)
+
+	assert.Error(t, err)
+}
+
+func TestComparisonPValue(t *testing.T) {
+	// scipy.stats.mannwhitneyu([6, 7, 8, 9, 10], [1, 2, 3, 4, 5], method='asymptotic') -> pvalue=0.01219
+	comparison := NewComparison(aStatsWith(1, 2, 3, 4, 5), aStatsWith(6, 7, 8, 9, 10))
+
+	pValue, err := comparison.PValue()
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.01219, pValue, 0.0001)
+}
+
+func TestComparisonPValueWithIdenticalSamples(t *testing.T) {
+	pValue, err := NewComparison(aStatsWith(1, 1, 1), aStatsWith(1, 1, 1)).PValue()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, pValue)
+}
+
+func TestComparisonPValueWithNoSamples(t *testing.T) {
+	_, err := NewComparison(aStatsWith(), aStatsWith(1, 1, 1)).PValue()
+
+	assert.Error(t, err)
+}
+
+func aStatsWith(samples ...int) api.Stats {
+	traces := []api.Trace{}
+	for _, sample := range samples {
+		traces = append(traces, aTraceWith("id", sample, nil))
+	}
+
+	if len(traces) == 0 {
+		return &_stats{}
+	}
+
+	return NewSummary(map[api.ID][]api.Trace{"id": traces}).PerceivedTimeStats("id")
+}
@@ -0,0 +1,152 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/sha1n/bert/api"
+)
+
+// ExecutionError reports the command failures of a benchmark that were not ignored, and how they affected the benchmark.
+type ExecutionError struct {
+	// Errors the number of reported command failures
+	Errors int
+	// Skipped the IDs of the scenarios that were skipped due to failures, in the order they were skipped
+	Skipped []api.ID
+	// Aborted whether the benchmark was stopped due to a failure
+	Aborted bool
+}
+
+func (e *ExecutionError) Error() string {
+	switch {
+	case e.Aborted:
+		return fmt.Sprintf("the benchmark was aborted after %d error(s)", e.Errors)
+	case len(e.Skipped) > 0:
+		return fmt.Sprintf("%d error(s) were reported and the following scenarios were skipped: '%s'", e.Errors, strings.Join(e.Skipped, "', '"))
+	default:
+		return fmt.Sprintf("%d error(s) were reported", e.Errors)
+	}
+}
+
+// errorHandler applies the error policies of a benchmark to command failures and keeps track of their effect on the benchmark.
+// All methods are safe for concurrent use.
+type errorHandler struct {
+	ctx      context.Context
+	abort    context.CancelFunc
+	spec     api.BenchmarkSpec
+	listener api.Listener
+	mx       *sync.Mutex
+	errors   int
+	errorsOf map[api.ID]int
+	skipped  []api.ID
+	aborted  bool
+}
+
+// newErrorHandler creates an error handler for the specified benchmark. The benchmark is aborted by cancelling the specified context.
+func newErrorHandler(ctx context.Context, abort context.CancelFunc, spec api.BenchmarkSpec, listener api.Listener) *errorHandler {
+	return &errorHandler{
+		ctx:      ctx,
+		abort:    abort,
+		spec:     spec,
+		listener: listener,
+		mx:       &sync.Mutex{},
+		errorsOf: map[api.ID]int{},
+	}
+}
+
+// handle applies the error policy of the specified command to its failure, if it failed.
+func (h *errorHandler) handle(progress *scenarioProgress, cmd *api.CommandSpec, err error) {
+	if err == nil {
+		return
+	}
+
+	scenario := progress.scenario
+	policy := h.spec.ErrorPolicy(cmd)
+	if policy == api.ErrorPolicyIgnore {
+		slog.Debug(fmt.Sprintf("Ignoring error of command %v in scenario '%s': %s", cmd.Cmd, scenario.ID(), err))
+		return
+	}
+
+	h.listener.OnError(scenario.ID(), err)
+
+	// failures of commands that are stopped along with the benchmark are reported, but don't affect its outcome
+	if h.ctx.Err() != nil {
+		return
+	}
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.errors++
+	h.errorsOf[scenario.ID()]++
+
+	switch {
+	case policy == api.ErrorPolicyAbort:
+		h.listener.OnMessage(scenario.ID(), "aborting the benchmark...")
+		h.aborted = true
+		h.abort()
+
+	case policy == api.ErrorPolicySkipScenario:
+		h.skip(progress, "skipping the remaining executions of this scenario...")
+
+	case scenario.MaxErrors > 0 && h.errorsOf[scenario.ID()] > scenario.MaxErrors:
+		h.skip(progress, fmt.Sprintf("more than %d errors reported, skipping the remaining executions of this scenario...", scenario.MaxErrors))
+	}
+}
+
+// handleBenchmarkHook applies the error policy of the specified benchmark level hook to its failure, if it failed.
+// Benchmark level hooks don't belong to any scenario, so the 'skip-scenario' policy aborts the benchmark.
+func (h *errorHandler) handleBenchmarkHook(name string, cmd *api.CommandSpec, err error) {
+	if err == nil {
+		return
+	}
+
+	policy := h.spec.ErrorPolicy(cmd)
+	if policy == api.ErrorPolicyIgnore {
+		slog.Debug(fmt.Sprintf("Ignoring error of '%s' command %v: %s", name, cmd.Cmd, err))
+		return
+	}
+
+	slog.Error(fmt.Sprintf("The '%s' command %v failed: %s", name, cmd.Cmd, err))
+
+	// failures of commands that are stopped along with the benchmark are reported, but don't affect its outcome
+	if h.ctx.Err() != nil {
+		return
+	}
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.errors++
+	if policy == api.ErrorPolicyAbort || policy == api.ErrorPolicySkipScenario {
+		slog.Error("Aborting the benchmark...")
+		h.aborted = true
+		h.abort()
+	}
+}
+
+func (h *errorHandler) skip(progress *scenarioProgress, message string) {
+	if progress.skip() {
+		h.listener.OnMessage(progress.scenario.ID(), message)
+		h.skipped = append(h.skipped, progress.scenario.ID())
+	}
+}
+
+// err returns an ExecutionError that describes the reported failures, or nil if no failures were reported.
+func (h *errorHandler) err() error {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if h.errors == 0 {
+		return nil
+	}
+
+	return &ExecutionError{
+		Errors:  h.errors,
+		Skipped: append([]api.ID{}, h.skipped...),
+		Aborted: h.aborted,
+	}
+}
@@ -0,0 +1,263 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithoutErrors(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(2)
+
+	err := Execute(context.Background(), spec, recordingExecutionContext())
+
+	assert.NoError(t, err)
+}
+
+func TestExecuteWithWarnPolicy(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(3)
+	executor := newFailingExecutor("cmd")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 3, Skipped: []api.ID{}}, err)
+	assert.Equal(t, 3, executor.executionsOf("cmd args"))
+	assert.Equal(t, 1, executor.executionsOf("after all"))
+}
+
+func TestExecuteWithIgnorePolicy(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(3)
+	spec.Scenarios[0].AfterEach.OnError = api.ErrorPolicyIgnore
+	executor := newFailingExecutor("after each")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, executor.executionsOf("cmd args"))
+}
+
+func TestExecuteWithSkipScenarioPolicy(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(3)
+	spec.Scenarios = append(spec.Scenarios, aBasicSpecWith(false, 3).Scenarios[0])
+	spec.Scenarios[0].BeforeEach.OnError = api.ErrorPolicySkipScenario
+	executor := newFailingExecutor("before each")
+	tracer := NewTracer(100)
+	listener := &countingListener{}
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(tracer, executor, listener))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{"scenario"}}, err)
+	assert.Equal(t, 1, listener.startsOf("scenario"), "skipped runs are not expected to be reported as executions")
+	assert.Equal(t, 1, listener.endsOf("scenario"), "skipped runs are not expected to be reported as executions")
+	assert.Equal(t, 3, listener.endsOf("scenario A"))
+	assert.Equal(t, 1, executor.executionsOf("cmd args"), "the run that failed is expected to complete")
+	assert.Equal(t, 1, executor.executionsOf("after all"), "the teardown of a skipped scenario is expected to run")
+	assert.Equal(t, 3, executor.executionsOf("cmd a"), "other scenarios are not expected to be affected")
+	assert.Equal(t, 1+3, len(tracer.Stream()))
+}
+
+func TestExecuteWithSkipScenarioPolicyInSetup(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(3)
+	spec.Warmup = 2
+	spec.Scenarios[0].BeforeAll.OnError = api.ErrorPolicySkipScenario
+	executor := newFailingExecutor("before all")
+	tracer := NewTracer(100)
+	listener := &countingListener{}
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(tracer, executor, listener))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{"scenario"}}, err)
+	assert.Equal(t, 0, executor.executionsOf("cmd args"))
+	assert.Zero(t, listener.startsOf("scenario"), "skipped runs are not expected to be reported as executions")
+	assert.Equal(t, 1, executor.executionsOf("after all"))
+	assert.Equal(t, 0, len(tracer.Stream()))
+}
+
+func TestExecuteWithMaxErrors(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(10)
+	spec.Scenarios[0].MaxErrors = 2
+	executor := newFailingExecutor("cmd")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 3, Skipped: []api.ID{"scenario"}}, err)
+	assert.Equal(t, 3, executor.executionsOf("cmd args"))
+}
+
+func TestExecuteWithAbortPolicy(t *testing.T) {
+	spec := aBasicSpecWith(false, 3)
+	spec.Scenarios[0].Command.OnError = api.ErrorPolicyAbort
+	executor := newFailingExecutor("cmd")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{}, Aborted: true}, err)
+	assert.Equal(t, 1, executor.executionsOf("cmd a"))
+	assert.Equal(t, 0, executor.executionsOf("cmd b"))
+}
+
+func TestExecuteWithFailFast(t *testing.T) {
+	spec := aBasicSpecWith(true, 3)
+	spec.FailFast = true
+	executor := newFailingExecutor("cmd")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	var executionErr *ExecutionError
+	assert.ErrorAs(t, err, &executionErr)
+	assert.True(t, executionErr.Aborted)
+	assert.Equal(t, 1, len(executor.executed))
+}
+
+func TestExecuteWithHookRetries(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(1)
+	spec.Scenarios[0].BeforeAll.Retries = 2
+	executor := newFailingExecutor("before all")
+	executor.failures = 2
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, executor.executionsOf("before all"))
+}
+
+func TestExecuteWithExhaustedHookRetries(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(1)
+	spec.Scenarios[0].BeforeAll.Retries = 2
+	executor := newFailingExecutor("before all")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{}}, err)
+	assert.Equal(t, 3, executor.executionsOf("before all"))
+}
+
+func TestExecuteWithFailingBenchmarkSetup(t *testing.T) {
+	spec := aBasicSpecWith(false, 2)
+	spec.Setup = &api.CommandSpec{Cmd: []string{"setup"}, Retries: 1}
+	spec.Teardown = &api.CommandSpec{Cmd: []string{"teardown"}}
+	executor := newFailingExecutor("setup")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{}}, err)
+	assert.Equal(t, 2, executor.executionsOf("setup"), "the setup is expected to be retried")
+	assert.Equal(t, 2, executor.executionsOf("cmd a"), "scenarios are expected to run with the warn policy")
+	assert.Equal(t, 1, executor.executionsOf("teardown"))
+}
+
+func TestExecuteWithFailingBenchmarkSetupAndAbortPolicy(t *testing.T) {
+	for _, policy := range []api.ErrorPolicy{api.ErrorPolicyAbort, api.ErrorPolicySkipScenario} {
+		spec := aBasicSpecWith(false, 2)
+		spec.Setup = &api.CommandSpec{Cmd: []string{"setup"}, OnError: policy}
+		spec.Teardown = &api.CommandSpec{Cmd: []string{"teardown"}}
+		executor := newFailingExecutor("setup")
+
+		err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+		assert.Equal(t, &ExecutionError{Errors: 1, Skipped: []api.ID{}, Aborted: true}, err)
+		assert.Equal(t, []string{"setup", "teardown"}, executor.executed, "the teardown is expected to run when the benchmark is aborted")
+	}
+}
+
+func TestExecuteWithFailingRoundHookAndIgnorePolicy(t *testing.T) {
+	spec := aBasicSpecWith(true, 2)
+	spec.AfterRound = &api.CommandSpec{Cmd: []string{"after round"}, OnError: api.ErrorPolicyIgnore}
+	executor := newFailingExecutor("after round")
+
+	err := Execute(context.Background(), spec, api.NewExecutionContext(NewTracer(100), executor, ui.NewLoggingProgressListener()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, executor.executionsOf("after round"))
+}
+
+func TestExecutionErrorMessage(t *testing.T) {
+	assert.EqualError(t, &ExecutionError{Errors: 2}, "2 error(s) were reported")
+	assert.EqualError(t, &ExecutionError{Errors: 2, Skipped: []api.ID{"a", "b"}}, "2 error(s) were reported and the following scenarios were skipped: 'a', 'b'")
+	assert.EqualError(t, &ExecutionError{Errors: 1, Aborted: true}, "the benchmark was aborted after 1 error(s)")
+}
+
+// failingExecutor an executor that fails commands that start with a specified prefix, and records all executed commands
+type failingExecutor struct {
+	prefix string
+	// failures the number of times to fail matching commands. Negative means always.
+	failures int
+	executed []string
+	mx       *sync.Mutex
+}
+
+func newFailingExecutor(prefix string) *failingExecutor {
+	return &failingExecutor{prefix: prefix, failures: -1, mx: &sync.Mutex{}}
+}
+
+func (e *failingExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	return func() (*api.ExecutionInfo, error) {
+		e.mx.Lock()
+		defer e.mx.Unlock()
+
+		cmdLine := strings.Join(cmd.Cmd, " ")
+		e.executed = append(e.executed, cmdLine)
+		if strings.HasPrefix(cmdLine, e.prefix) && e.failures != 0 {
+			e.failures--
+			return nil, errors.New("failed")
+		}
+
+		return &api.ExecutionInfo{}, nil
+	}
+}
+
+func (e *failingExecutor) executionsOf(cmdLine string) (count int) {
+	for _, executed := range e.executed {
+		if executed == cmdLine {
+			count++
+		}
+	}
+
+	return count
+}
+
+// countingListener counts the scenario start and end events it is notified of
+type countingListener struct {
+	ui.LoggingProgressListener
+	mx     sync.Mutex
+	starts map[api.ID]int
+	ends   map[api.ID]int
+}
+
+func (l *countingListener) OnScenarioStart(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.starts == nil {
+		l.starts = map[api.ID]int{}
+	}
+	l.starts[id]++
+}
+
+func (l *countingListener) OnScenarioEnd(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.ends == nil {
+		l.ends = map[api.ID]int{}
+	}
+	l.ends[id]++
+}
+
+func (l *countingListener) startsOf(id api.ID) int {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	return l.starts[id]
+}
+
+func (l *countingListener) endsOf(id api.ID) int {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	return l.ends[id]
+}
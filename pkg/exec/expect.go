@@ -0,0 +1,100 @@
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"slices"
+
+	"github.com/sha1n/bert/api"
+)
+
+// expectation checks the outcome of a command execution against the expectations of its spec.
+// Output streams are captured only if the expectations refer to them.
+type expectation struct {
+	spec   *api.ExpectSpec
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+// newExpectation returns an expectation for the specified spec, or nil if the spec is nil.
+func newExpectation(spec *api.ExpectSpec) *expectation {
+	if spec == nil {
+		return nil
+	}
+
+	return &expectation{spec: spec}
+}
+
+// capture captures the output streams the expectation refers to, in addition to writing them to their current destinations.
+func (e *expectation) capture(execCmd *exec.Cmd) {
+	if e.spec.StdoutMatches != "" || e.spec.StdoutNotMatches != "" {
+		e.stdout = new(bytes.Buffer)
+		execCmd.Stdout = teeTo(execCmd.Stdout, e.stdout)
+	}
+	if e.spec.StderrMatches != "" || e.spec.StderrNotMatches != "" {
+		e.stderr = new(bytes.Buffer)
+		execCmd.Stderr = teeTo(execCmd.Stderr, e.stderr)
+	}
+}
+
+// check returns an error if the execution of the specified command doesn't meet the expectation.
+// The specified error is the one returned by running the command. Errors other than a non-zero exit code are returned as is.
+func (e *expectation) check(cmd []string, runErr error) error {
+	exitCode := 0
+	if runErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) || exitErr.ExitCode() < 0 {
+			return runErr
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	if len(e.spec.ExitCode) == 0 && runErr != nil {
+		return runErr
+	}
+	if len(e.spec.ExitCode) > 0 && !slices.Contains(e.spec.ExitCode, exitCode) {
+		return fmt.Errorf("command %v exited with code %d, expected one of %v", cmd, exitCode, e.spec.ExitCode)
+	}
+
+	for _, m := range []struct {
+		name    string
+		output  *bytes.Buffer
+		pattern string
+		match   bool
+	}{
+		{"stdout", e.stdout, e.spec.StdoutMatches, true},
+		{"stdout", e.stdout, e.spec.StdoutNotMatches, false},
+		{"stderr", e.stderr, e.spec.StderrMatches, true},
+		{"stderr", e.stderr, e.spec.StderrNotMatches, false},
+	} {
+		if m.pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(m.pattern)
+		if err != nil {
+			return fmt.Errorf("invalid %s pattern '%s': %w", m.name, m.pattern, err)
+		}
+		if re.Match(m.output.Bytes()) != m.match {
+			if m.match {
+				return fmt.Errorf("command %v %s doesn't match '%s'", cmd, m.name, m.pattern)
+			}
+			return fmt.Errorf("command %v %s matches '%s'", cmd, m.name, m.pattern)
+		}
+	}
+
+	return nil
+}
+
+// teeTo returns a writer that writes to both of the specified writers, or to the second one if the first one is nil.
+func teeTo(w io.Writer, other io.Writer) io.Writer {
+	if w == nil {
+		return other
+	}
+
+	return io.MultiWriter(w, other)
+}
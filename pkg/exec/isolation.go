@@ -0,0 +1,40 @@
+package exec
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/sha1n/bert/api"
+)
+
+// isolationKey the context key of the isolation settings of a command
+type isolationKey struct{}
+
+// withIsolation returns a copy of the specified context that applies the specified isolation settings to the commands
+// executed with it. Returns the specified context if no settings are specified.
+func withIsolation(ctx context.Context, isolation *api.IsolationSpec) context.Context {
+	if isolation == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, isolationKey{}, isolation)
+}
+
+// isolationOf returns the isolation settings carried by the specified context, or nil if it doesn't carry any.
+func isolationOf(ctx context.Context) *api.IsolationSpec {
+	isolation, _ := ctx.Value(isolationKey{}).(*api.IsolationSpec)
+
+	return isolation
+}
+
+// runIsolated runs the specified command with the specified isolation settings, or as is if no settings are specified.
+func runIsolated(execCmd *exec.Cmd, isolation *api.IsolationSpec) error {
+	if isolation == nil {
+		return execCmd.Run()
+	}
+	if err := startIsolated(execCmd, isolation); err != nil {
+		return err
+	}
+
+	return execCmd.Wait()
+}
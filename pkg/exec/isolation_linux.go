@@ -0,0 +1,122 @@
+package exec
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"github.com/sha1n/bert/api"
+	"golang.org/x/sys/unix"
+)
+
+// rlimitResources the resources that can be limited by name
+var rlimitResources = map[string]int{
+	"as":      unix.RLIMIT_AS,
+	"core":    unix.RLIMIT_CORE,
+	"cpu":     unix.RLIMIT_CPU,
+	"data":    unix.RLIMIT_DATA,
+	"fsize":   unix.RLIMIT_FSIZE,
+	"memlock": unix.RLIMIT_MEMLOCK,
+	"nofile":  unix.RLIMIT_NOFILE,
+	"nproc":   unix.RLIMIT_NPROC,
+	"stack":   unix.RLIMIT_STACK,
+}
+
+const (
+	ioprioWhoProcess = 1
+	ioprioClassBE    = 2
+	ioprioClassShift = 13
+)
+
+// startIsolated starts the specified command with the specified isolation settings.
+// A new process inherits the CPU affinity and the scheduling priorities of the thread that starts it, so these are
+// applied to a dedicated thread, which is discarded once the command is started.
+func startIsolated(execCmd *exec.Cmd, isolation *api.IsolationSpec) error {
+	started := make(chan error, 1)
+	go func() {
+		// the thread is never unlocked, so it is terminated along with this goroutine instead of being reused with modified settings
+		runtime.LockOSThread()
+		if err := isolateCurrentThread(execCmd, isolation); err != nil {
+			started <- err
+			return
+		}
+		started <- startWithRlimits(execCmd, isolation.Rlimits)
+	}()
+
+	return <-started
+}
+
+// startWithRlimits starts the specified command with the specified resource limits. Resource limits are process wide,
+// so they cannot be inherited from the starting thread without affecting other commands. Instead, the command is traced
+// so it stops right after it is executed, before it runs any code or forks, the limits are applied to it and it is
+// released. Tracing must be done by a single thread, so this is expected to be called on a locked thread.
+func startWithRlimits(execCmd *exec.Cmd, rlimits map[string]uint64) error {
+	if len(rlimits) == 0 {
+		return execCmd.Start()
+	}
+
+	if execCmd.SysProcAttr == nil {
+		execCmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	execCmd.SysProcAttr.Ptrace = true
+	if err := execCmd.Start(); err != nil {
+		return err
+	}
+
+	err := applyRlimits(execCmd, rlimits)
+	if err != nil {
+		_ = execCmd.Process.Kill()
+		_ = execCmd.Wait()
+	}
+
+	return err
+}
+
+func applyRlimits(execCmd *exec.Cmd, rlimits map[string]uint64) error {
+	pid := execCmd.Process.Pid
+	var status unix.WaitStatus
+	if _, err := unix.Wait4(pid, &status, unix.WALL, nil); err != nil {
+		return fmt.Errorf("failed to set the resource limits of command %v. %w", execCmd.Args, err)
+	}
+	if !status.Stopped() {
+		return fmt.Errorf("failed to set the resource limits of command %v. the command exited before they could be set", execCmd.Args)
+	}
+
+	for name, limit := range rlimits {
+		rlimit := unix.Rlimit{Cur: limit, Max: limit}
+		if err := unix.Prlimit(pid, rlimitResources[name], &rlimit, nil); err != nil {
+			return fmt.Errorf("failed to set the '%s' limit of command %v to %d. %w", name, execCmd.Args, limit, err)
+		}
+	}
+	if err := unix.PtraceDetach(pid); err != nil {
+		return fmt.Errorf("failed to set the resource limits of command %v. %w", execCmd.Args, err)
+	}
+
+	return nil
+}
+
+func isolateCurrentThread(execCmd *exec.Cmd, isolation *api.IsolationSpec) error {
+	if len(isolation.CPUs) > 0 {
+		var cpus unix.CPUSet
+		for _, cpu := range isolation.CPUs {
+			cpus.Set(cpu)
+		}
+		if err := unix.SchedSetaffinity(0, &cpus); err != nil {
+			return fmt.Errorf("failed to set the CPU affinity of command %v to %v. %w", execCmd.Args, isolation.CPUs, err)
+		}
+	}
+	if isolation.Nice != nil {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, 0, *isolation.Nice); err != nil {
+			return fmt.Errorf("failed to set the nice value of command %v to %d. %w", execCmd.Args, *isolation.Nice, err)
+		}
+	}
+	if isolation.IONice != nil {
+		prio := ioprioClassBE<<ioprioClassShift | *isolation.IONice
+		if _, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, uintptr(prio)); errno != 0 {
+			return fmt.Errorf("failed to set the I/O priority of command %v to %d. %w", execCmd.Args, *isolation.IONice, errno)
+		}
+	}
+
+	return nil
+}
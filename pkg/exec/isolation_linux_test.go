@@ -0,0 +1,81 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestExecCommandFnWithIsolation(t *testing.T) {
+	var allowed unix.CPUSet
+	assert.NoError(t, unix.SchedGetaffinity(0, &allowed))
+	cpu := firstCPUOf(allowed)
+	nice, ioNice := 19, 7
+	isolation := &api.IsolationSpec{CPUs: []int{cpu}, Nice: &nice, IONice: &ioNice, Rlimits: map[string]uint64{"nofile": 64}}
+
+	stdout := new(bytes.Buffer)
+	ctx := withCapturedOutput(withIsolation(context.Background(), isolation), stdout, io.Discard)
+	spec := &api.CommandSpec{Cmd: []string{"grep Cpus_allowed_list /proc/self/status | cut -f2 && nice && ulimit -n"}, Shell: api.DefaultShell}
+
+	_, err := NewCommandExecutor(false, false, io.Discard).ExecuteFn(ctx, spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d\n19\n64\n", cpu), stdout.String())
+}
+
+func TestExecCommandFnWithRlimitsAppliesThemBeforeTheCommandRuns(t *testing.T) {
+	isolation := &api.IsolationSpec{Rlimits: map[string]uint64{"nofile": 64}}
+	executor := NewCommandExecutor(false, false, io.Discard)
+	// the limit is read by a child of the command, which is forked as soon as the command starts
+	spec := &api.CommandSpec{Cmd: []string{"sh -c 'ulimit -n'"}, Shell: api.DefaultShell}
+
+	for range 20 {
+		stdout := new(bytes.Buffer)
+		ctx := withCapturedOutput(withIsolation(context.Background(), isolation), stdout, io.Discard)
+
+		_, err := executor.ExecuteFn(ctx, spec, "", nil)()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "64\n", stdout.String())
+	}
+}
+
+func TestExecCommandFnWithIsolationDoesNotAffectOtherCommands(t *testing.T) {
+	nice := 19
+	executor := NewCommandExecutor(false, false, io.Discard)
+	spec := &api.CommandSpec{Cmd: []string{"nice"}}
+	_, err := executor.ExecuteFn(withIsolation(context.Background(), &api.IsolationSpec{Nice: &nice}), spec, "", nil)()
+	assert.NoError(t, err)
+
+	// a command executed right after an isolated one is likely to be started by the same goroutine
+	stdout := new(bytes.Buffer)
+	_, err = executor.ExecuteFn(withCapturedOutput(context.Background(), stdout, io.Discard), spec, "", nil)()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "19\n", stdout.String())
+}
+
+func TestExecCommandFnWithFailingIsolation(t *testing.T) {
+	isolation := &api.IsolationSpec{CPUs: []int{1023}}
+	spec := &api.CommandSpec{Cmd: []string{"true"}}
+
+	_, err := NewCommandExecutor(false, false, io.Discard).ExecuteFn(withIsolation(context.Background(), isolation), spec, "", nil)()
+
+	assert.ErrorContains(t, err, "failed to set the CPU affinity of command [true]")
+}
+
+func firstCPUOf(cpus unix.CPUSet) int {
+	for cpu := range len(cpus) * 64 {
+		if cpus.IsSet(cpu) {
+			return cpu
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,24 @@
+//go:build !linux
+
+package exec
+
+import (
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"runtime"
+	"sync"
+
+	"github.com/sha1n/bert/api"
+)
+
+var warnIsolationUnsupported = sync.OnceFunc(func() {
+	slog.Warn(fmt.Sprintf("Scenario isolation is not supported on %s and is ignored", runtime.GOOS))
+})
+
+// startIsolated isolation is not supported on this platform. The command is started as is.
+func startIsolated(execCmd *exec.Cmd, isolation *api.IsolationSpec) error {
+	warnIsolationUnsupported()
+
+	return execCmd.Start()
+}
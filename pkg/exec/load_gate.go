@@ -0,0 +1,88 @@
+package exec
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/osutil"
+)
+
+// loadPollInterval how often the load average of the host is sampled while waiting for it to drop
+const loadPollInterval = time.Second
+
+// loadGate delays measured runs until the 1 minute load average of the host drops below a threshold, or until
+// the maximum wait time elapses. A nil loadGate doesn't delay anything.
+type loadGate struct {
+	maxLoad      float64
+	maxWait      time.Duration
+	pollInterval time.Duration
+	loadAverage  func() ([]float64, error)
+	warnOnce     sync.Once
+}
+
+// newLoadGate returns a load gate with the specified threshold and maximum wait time, or nil if no threshold is specified.
+func newLoadGate(maxLoad float64, maxWait time.Duration) *loadGate {
+	if maxLoad <= 0 {
+		return nil
+	}
+
+	return &loadGate{
+		maxLoad:      maxLoad,
+		maxWait:      cmp.Or(maxWait, api.DefaultSettle.Duration()),
+		pollInterval: loadPollInterval,
+		loadAverage:  osutil.LoadAverage,
+	}
+}
+
+// wait blocks until the load average of the host drops below the threshold, the maximum wait time elapses or the
+// context is cancelled, and returns the time it waited. Runs are not delayed if the load average cannot be read.
+func (g *loadGate) wait(ctx context.Context, id api.ID, listener api.Listener) time.Duration {
+	if g == nil {
+		return 0
+	}
+
+	startTime := time.Now()
+	deadline := startTime.Add(g.maxWait)
+	for {
+		load, err := g.currentLoad()
+		if err != nil {
+			g.warnOnce.Do(func() {
+				slog.Warn(fmt.Sprintf("Failed to read the load average of the host, runs are not delayed. %s", err))
+			})
+			return time.Since(startTime)
+		}
+		if load < g.maxLoad {
+			return time.Since(startTime)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			listener.OnMessagef(id, "load average %.2f is still above %.2f after %s, starting run", load, g.maxLoad, g.maxWait)
+			return time.Since(startTime)
+		}
+
+		listener.OnMessagef(id, "load average %.2f is above %.2f, waiting...", load, g.maxLoad)
+		select {
+		case <-ctx.Done():
+			return time.Since(startTime)
+		case <-time.After(min(g.pollInterval, remaining)):
+		}
+	}
+}
+
+func (g *loadGate) currentLoad() (float64, error) {
+	loads, err := g.loadAverage()
+	if err == nil && len(loads) == 0 {
+		err = fmt.Errorf("no load average reported")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return loads[0], nil
+}
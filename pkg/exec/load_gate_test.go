@@ -0,0 +1,87 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoadGate(t *testing.T) {
+	assert.Nil(t, newLoadGate(0, time.Second))
+
+	assert.Equal(t, time.Second, newLoadGate(1.5, time.Second).maxWait)
+	assert.Equal(t, api.DefaultSettle.Duration(), newLoadGate(1.5, 0).maxWait)
+}
+
+func TestLoadGateWithoutThreshold(t *testing.T) {
+	var gate *loadGate
+
+	assert.Equal(t, time.Duration(0), gate.wait(context.Background(), "a", ui.NewLoggingProgressListener()))
+}
+
+func TestLoadGateDoesNotWaitForQuietHost(t *testing.T) {
+	gate, samples := aLoadGateWith(time.Hour, 0.5)
+
+	gate.wait(context.Background(), "a", ui.NewLoggingProgressListener())
+
+	assert.Equal(t, 1, *samples)
+}
+
+func TestLoadGateWaitsForLoadToDrop(t *testing.T) {
+	gate, samples := aLoadGateWith(time.Hour, 3, 2, 1.5, 0.5)
+
+	wait := gate.wait(context.Background(), "a", ui.NewLoggingProgressListener())
+
+	assert.Equal(t, 4, *samples)
+	assert.GreaterOrEqual(t, wait, 3*gate.pollInterval)
+}
+
+func TestLoadGateWaitsUpToMaxWait(t *testing.T) {
+	gate, _ := aLoadGateWith(50*time.Millisecond, 3)
+
+	wait := gate.wait(context.Background(), "a", ui.NewLoggingProgressListener())
+
+	assert.GreaterOrEqual(t, wait, gate.maxWait)
+	assert.Less(t, wait, time.Second)
+}
+
+func TestLoadGateStopsWaitingWhenCancelled(t *testing.T) {
+	gate, _ := aLoadGateWith(time.Hour, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wait := gate.wait(ctx, "a", ui.NewLoggingProgressListener())
+
+	assert.Less(t, wait, time.Second)
+}
+
+func TestLoadGateDoesNotWaitWhenLoadIsUnavailable(t *testing.T) {
+	gate := newLoadGate(1, time.Hour)
+	gate.loadAverage = func() ([]float64, error) { return nil, errors.New("unsupported") }
+
+	assert.Less(t, gate.wait(context.Background(), "a", ui.NewLoggingProgressListener()), time.Second)
+
+	gate.loadAverage = func() ([]float64, error) { return []float64{}, nil }
+	assert.Less(t, gate.wait(context.Background(), "a", ui.NewLoggingProgressListener()), time.Second)
+}
+
+// aLoadGateWith returns a load gate with a threshold of 1 that samples the specified loads in order, repeating
+// the last one, and a pointer to the number of samples taken.
+func aLoadGateWith(maxWait time.Duration, loads ...float64) (*loadGate, *int) {
+	samples := 0
+	gate := newLoadGate(1, maxWait)
+	gate.pollInterval = time.Millisecond
+	gate.loadAverage = func() ([]float64, error) {
+		load := loads[min(samples, len(loads)-1)]
+		samples++
+
+		return []float64{load, 0, 0}, nil
+	}
+
+	return gate, &samples
+}
@@ -0,0 +1,33 @@
+package exec
+
+import (
+	"github.com/montanaflynn/stats"
+)
+
+// tukeyFenceFactor the IQR multiplier used to calculate Tukey's inner fences
+const tukeyFenceFactor = 1.5
+
+// classifyOutliers splits the specified samples into inliers and outliers using Tukey's fences.
+// A sample is an outlier if it lies more than 1.5 IQR below the first quartile, or above the third quartile.
+// Samples that are too few to calculate quartiles are all classified as inliers.
+func classifyOutliers(samples stats.Float64Data) (inliers stats.Float64Data, outliers stats.Float64Data) {
+	quartiles, err := stats.Quartile(samples)
+	if err != nil || len(samples) < 4 {
+		return samples, stats.Float64Data{}
+	}
+
+	iqr := quartiles.Q3 - quartiles.Q1
+	lowerFence, upperFence := quartiles.Q1-tukeyFenceFactor*iqr, quartiles.Q3+tukeyFenceFactor*iqr
+
+	inliers = make(stats.Float64Data, 0, len(samples))
+	outliers = stats.Float64Data{}
+	for _, sample := range samples {
+		if sample < lowerFence || sample > upperFence {
+			outliers = append(outliers, sample)
+		} else {
+			inliers = append(inliers, sample)
+		}
+	}
+
+	return inliers, outliers
+}
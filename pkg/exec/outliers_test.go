@@ -0,0 +1,33 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/montanaflynn/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyOutliers(t *testing.T) {
+	inliers, outliers := classifyOutliers(stats.Float64Data{10, 11, 12, 11, 10, 12, 11, 100, 1})
+
+	assert.Equal(t, stats.Float64Data{10, 11, 12, 11, 10, 12, 11}, inliers)
+	assert.Equal(t, stats.Float64Data{100, 1}, outliers)
+}
+
+func TestClassifyOutliersWithoutOutliers(t *testing.T) {
+	samples := stats.Float64Data{10, 11, 12, 13, 14}
+
+	inliers, outliers := classifyOutliers(samples)
+
+	assert.Equal(t, samples, inliers)
+	assert.Empty(t, outliers)
+}
+
+func TestClassifyOutliersWithTooFewSamples(t *testing.T) {
+	samples := stats.Float64Data{1, 100, 10000}
+
+	inliers, outliers := classifyOutliers(samples)
+
+	assert.Equal(t, samples, inliers)
+	assert.Empty(t, outliers)
+}
@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sha1n/bert/api"
+)
+
+// capturedOutputKey the context key of the writers the output of a command is captured to
+type capturedOutputKey struct{}
+
+type capturedOutput struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// withCapturedOutput returns a copy of the specified context that directs the output of the commands executed with it
+// to the specified writers, in addition to their usual destinations.
+func withCapturedOutput(ctx context.Context, stdout io.Writer, stderr io.Writer) context.Context {
+	return context.WithValue(ctx, capturedOutputKey{}, capturedOutput{stdout: stdout, stderr: stderr})
+}
+
+// capturedOutputOf returns the writers carried by the specified context, or false if it doesn't carry any.
+func capturedOutputOf(ctx context.Context) (capturedOutput, bool) {
+	output, ok := ctx.Value(capturedOutputKey{}).(capturedOutput)
+
+	return output, ok
+}
+
+// outputCapture saves the output of measured runs of benchmarked commands to files in a directory.
+// A nil outputCapture doesn't capture anything.
+type outputCapture struct {
+	dir string
+}
+
+// newOutputCapture returns an output capture that saves files to the specified directory, or nil if no directory is specified.
+func newOutputCapture(dir string) *outputCapture {
+	if dir == "" {
+		return nil
+	}
+
+	return &outputCapture{dir: dir}
+}
+
+// start creates the output files of the specified run and returns a context that directs the output of the benchmarked
+// command to them, and a function that closes them. The returned function adds the paths of the files to the error
+// of a failed run, so the output of the run can be found.
+// Runs whose files cannot be created are executed without capturing their output.
+func (c *outputCapture) start(ctx context.Context, run api.RunContext) (context.Context, func(error) error) {
+	if c == nil {
+		return ctx, func(err error) error { return err }
+	}
+
+	basePath := runOutputPath(c.dir, run)
+	stdout, stderr, err := createOutputFiles(basePath)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Failed to capture the output of run %d of scenario '%s'. %s", run.Index, run.Scenario, err))
+		return ctx, func(err error) error { return err }
+	}
+
+	return withCapturedOutput(ctx, stdout, stderr), func(err error) error {
+		_ = stdout.Close()
+		_ = stderr.Close()
+		if err != nil {
+			return fmt.Errorf("%w. output saved to '%s.out' and '%s.err'", err, basePath, basePath)
+		}
+
+		return nil
+	}
+}
+
+func createOutputFiles(basePath string) (stdout *os.File, stderr *os.File, err error) {
+	if err = os.MkdirAll(filepath.Dir(basePath), 0755); err != nil {
+		return nil, nil, err
+	}
+	if stdout, err = os.Create(basePath + ".out"); err != nil {
+		return nil, nil, err
+	}
+	if stderr, err = os.Create(basePath + ".err"); err != nil {
+		_ = stdout.Close()
+		return nil, nil, err
+	}
+
+	return stdout, stderr, nil
+}
+
+// runOutputPath returns the path of the output files of the specified run, without an extension.
+func runOutputPath(dir string, run api.RunContext) string {
+	return filepath.Join(dir, fileNameOf(run.Scenario), fmt.Sprintf("run-%d", run.Index))
+}
+
+// fileNameOf returns a file name for the specified scenario, with characters that are not allowed in file names replaced.
+func fileNameOf(id api.ID) string {
+	name := strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, id)
+	// names such as '..' would refer to a directory other than the scenario directory
+	if strings.Trim(name, ".") == "" {
+		return strings.ReplaceAll(name, ".", "_")
+	}
+
+	return name
+}
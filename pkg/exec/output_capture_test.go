@@ -0,0 +1,85 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBenchmarkCapturesOutputOfMeasuredRuns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("'sh' is not expected to be available on " + runtime.GOOS)
+	}
+
+	dir := t.TempDir()
+	spec := api.BenchmarkSpec{
+		Executions:    2,
+		Warmup:        1,
+		CaptureOutput: dir,
+		Scenarios: []api.ScenarioSpec{
+			{
+				Name:       "build/debug",
+				BeforeEach: &api.CommandSpec{Cmd: []string{"echo hook"}, Shell: api.DefaultShell},
+				Command:    &api.CommandSpec{Cmd: []string{"echo run ${BERT_RUN_INDEX} && echo err >&2"}, Shell: api.DefaultShell},
+			},
+		},
+	}
+	execCtx := api.NewExecutionContext(NewTracer(10), NewCommandExecutor(false, false, io.Discard), ui.NewLoggingProgressListener())
+
+	assert.NoError(t, Execute(context.Background(), spec, execCtx))
+
+	for i := 1; i <= 2; i++ {
+		assert.Equal(t, fmt.Sprintf("run %d\n", i), fileContentOf(t, filepath.Join(dir, "build_debug", fmt.Sprintf("run-%d.out", i))))
+		assert.Equal(t, "err\n", fileContentOf(t, filepath.Join(dir, "build_debug", fmt.Sprintf("run-%d.err", i))))
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "build_debug", "run-0.out"), "warmup runs are not expected to be captured")
+}
+
+func TestOutputCaptureAddsOutputPathsToErrors(t *testing.T) {
+	dir := t.TempDir()
+	capture := newOutputCapture(dir)
+
+	_, endCapture := capture.start(context.Background(), api.RunContext{Scenario: "a", Index: 3, Total: 5})
+	err := endCapture(api.ErrTimeout)
+
+	basePath := filepath.Join(dir, "a", "run-3")
+	assert.ErrorIs(t, err, api.ErrTimeout)
+	assert.EqualError(t, err, fmt.Sprintf("%s. output saved to '%s.out' and '%s.err'", api.ErrTimeout, basePath, basePath))
+
+	_, endCapture = capture.start(context.Background(), api.RunContext{Scenario: "a", Index: 4, Total: 5})
+	assert.NoError(t, endCapture(nil))
+}
+
+func TestOutputCaptureWithoutDir(t *testing.T) {
+	capture := newOutputCapture("")
+	err := errors.New("failed")
+
+	ctx, endCapture := capture.start(context.Background(), api.RunContext{Scenario: "a", Index: 1, Total: 1})
+
+	_, captured := capturedOutputOf(ctx)
+	assert.False(t, captured)
+	assert.Equal(t, err, endCapture(err))
+}
+
+func TestFileNameOf(t *testing.T) {
+	assert.Equal(t, "build debug", fileNameOf("build debug"))
+	assert.Equal(t, "a_b_c_d", fileNameOf(`a/b\c:d`))
+	assert.Equal(t, "__", fileNameOf(".."))
+	assert.Equal(t, "v1.2", fileNameOf("v1.2"))
+}
+
+func fileContentOf(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	return string(content)
+}
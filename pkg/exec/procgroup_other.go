@@ -0,0 +1,10 @@
+//go:build !unix
+
+package exec
+
+import (
+	"os/exec"
+)
+
+// configureProcessGroup is a no-op on platforms without process groups. Context cancellation kills the command process only.
+func configureProcessGroup(execCmd *exec.Cmd) {}
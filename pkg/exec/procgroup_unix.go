@@ -0,0 +1,17 @@
+//go:build unix
+
+package exec
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureProcessGroup starts the specified command in a new process group and makes context cancellation kill the whole group,
+// so that child processes of the command don't outlive it.
+func configureProcessGroup(execCmd *exec.Cmd) {
+	execCmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	execCmd.Cancel = func() error {
+		return syscall.Kill(-execCmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,4 @@
+package exec
+
+// maxRSSUnit macOS reports the max RSS in bytes
+const maxRSSUnit = 1
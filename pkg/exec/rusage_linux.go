@@ -0,0 +1,4 @@
+package exec
+
+// maxRSSUnit Linux reports the max RSS in kilobytes
+const maxRSSUnit = 1024
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package exec
+
+import (
+	"os"
+
+	"github.com/sha1n/bert/api"
+)
+
+// resourceUsageOf resource usage is not supported on this platform and is always reported as zero.
+func resourceUsageOf(state *os.ProcessState) api.ResourceUsage {
+	return api.ResourceUsage{}
+}
@@ -0,0 +1,28 @@
+//go:build linux || darwin
+
+package exec
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/sha1n/bert/api"
+)
+
+// resourceUsageOf returns the resource usage of the specified exited process.
+func resourceUsageOf(state *os.ProcessState) api.ResourceUsage {
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || rusage == nil {
+		return api.ResourceUsage{}
+	}
+
+	return api.ResourceUsage{
+		MaxRSS:                     int64(rusage.Maxrss) * maxRSSUnit,
+		MinorPageFaults:            int64(rusage.Minflt),
+		MajorPageFaults:            int64(rusage.Majflt),
+		VoluntaryContextSwitches:   int64(rusage.Nvcsw),
+		InvoluntaryContextSwitches: int64(rusage.Nivcsw),
+		BlockInputOps:              int64(rusage.Inblock),
+		BlockOutputOps:             int64(rusage.Oublock),
+	}
+}
@@ -0,0 +1,239 @@
+package exec
+
+import (
+	"cmp"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"github.com/sha1n/bert/api"
+)
+
+// scenarioProgress tracks the executions of a single scenario and decides when the scenario is done.
+// All methods are safe for concurrent use.
+type scenarioProgress struct {
+	scenario      api.ScenarioSpec
+	adaptive      *api.AdaptiveSpec
+	minExecutions int
+	maxExecutions int
+	maxDuration   time.Duration
+	deadline      time.Time
+	started       int
+	inFlight      int
+	executions    int
+	samples       stats.Float64Data
+	elapsed       time.Duration
+	finished      bool
+	skipped       bool
+	setupOnce     *sync.Once
+	mx            *sync.Mutex
+}
+
+// newScenarioProgress creates the progress of the specified scenario. A scenario level number of executions takes
+// precedence over adaptive execution, and a scenario level budget takes precedence over the adaptive execution budget.
+func newScenarioProgress(spec api.BenchmarkSpec, scenario api.ScenarioSpec) *scenarioProgress {
+	p := &scenarioProgress{
+		scenario:      scenario,
+		minExecutions: spec.MaxExecutionsOf(scenario),
+		maxExecutions: spec.MaxExecutionsOf(scenario),
+		maxDuration:   scenario.MaxDuration.Duration(),
+		setupOnce:     &sync.Once{},
+		mx:            &sync.Mutex{},
+	}
+
+	if spec.Adaptive != nil && scenario.Executions == 0 {
+		p.adaptive = spec.Adaptive
+		p.minExecutions = spec.Adaptive.MinExecutions
+		p.maxDuration = cmp.Or(p.maxDuration, spec.Adaptive.MaxDuration.Duration())
+	}
+	// a budget can stop a scenario with a fixed number of executions after any execution
+	if p.adaptive == nil && p.maxDuration > 0 {
+		p.minExecutions = 1
+	}
+
+	return p
+}
+
+// start reserves the next execution of this scenario and returns its index.
+// Returns false if the scenario is done, or if all of its remaining executions are already in progress.
+func (p *scenarioProgress) start() (int, bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.isDone() || p.started >= p.maxExecutions {
+		return 0, false
+	}
+
+	p.started++
+	p.inFlight++
+
+	return p.started, true
+}
+
+// runContext returns the run context of the specified run of this scenario.
+func (p *scenarioProgress) runContext(execIndex int) api.RunContext {
+	return api.RunContext{Scenario: p.scenario.ID(), Index: execIndex, Total: p.maxExecutions}
+}
+
+// record records the result of a single execution and the wall-clock time it took, including hooks.
+// Returns true if this is the last execution of this scenario, in which case the scenario should be torn down.
+func (p *scenarioProgress) record(info *api.ExecutionInfo, elapsed time.Duration) (last bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.executions++
+	p.inFlight = max(p.inFlight-1, 0)
+	p.elapsed += elapsed
+	if info != nil {
+		p.samples = append(p.samples, float64(info.PerceivedTime))
+	}
+
+	if p.isDone() && p.inFlight == 0 && !p.finished {
+		p.finished = true
+		return true
+	}
+
+	return false
+}
+
+func (p *scenarioProgress) done() bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return p.isDone()
+}
+
+// skip marks this scenario as done, so no more executions of it are started.
+// Returns false if the scenario has already been skipped.
+func (p *scenarioProgress) skip() bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.skipped {
+		return false
+	}
+	p.skipped = true
+
+	return true
+}
+
+func (p *scenarioProgress) isSkipped() bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return p.skipped
+}
+
+// stoppedByDeadline returns true if the deadline of this scenario has passed before it was complete.
+func (p *scenarioProgress) stoppedByDeadline() bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return !p.isComplete() && p.deadlinePassed()
+}
+
+func (p *scenarioProgress) isDone() bool {
+	return p.isComplete() || p.deadlinePassed()
+}
+
+func (p *scenarioProgress) isComplete() bool {
+	if p.skipped || p.executions >= p.maxExecutions {
+		return true
+	}
+	if p.executions < p.minExecutions {
+		return false
+	}
+
+	return p.budgetSpent() || p.targetReached()
+}
+
+// isEstimated returns true if the number of executions of this scenario is not known in advance.
+func (p *scenarioProgress) isEstimated() bool {
+	return p.minExecutions < p.maxExecutions || !p.deadline.IsZero()
+}
+
+// finish marks this scenario as finished and returns true if it has been started, but hasn't finished yet,
+// in which case the scenario should be torn down.
+func (p *scenarioProgress) finish() bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.started == 0 || p.finished {
+		return false
+	}
+	p.finished = true
+
+	return true
+}
+
+// expectedExecutions returns an estimate of the total number of executions of this scenario.
+func (p *scenarioProgress) expectedExecutions() int {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.isDone() {
+		return p.executions
+	}
+	if p.minExecutions == p.maxExecutions || p.executions == 0 {
+		return p.maxExecutions
+	}
+
+	expected := p.maxExecutions
+	if p.adaptive != nil && p.adaptive.TargetRSE > 0 {
+		if rse, ok := p.relativeStdErr(); ok && rse > 0 {
+			// the standard error of the mean shrinks by the square root of the number of samples
+			needed := int(math.Ceil(float64(len(p.samples)) * math.Pow(rse/p.adaptive.TargetRSE, 2)))
+			expected = min(expected, needed)
+		}
+	}
+	if p.maxDuration > 0 {
+		meanElapsed := float64(p.elapsed) / float64(p.executions)
+		if meanElapsed > 0 {
+			remaining := float64(p.maxDuration - p.elapsed)
+			expected = min(expected, p.executions+int(math.Ceil(remaining/meanElapsed)))
+		}
+	}
+
+	return max(expected, p.minExecutions, p.executions+1)
+}
+
+func (p *scenarioProgress) budgetSpent() bool {
+	return p.maxDuration > 0 && p.elapsed >= p.maxDuration
+}
+
+func (p *scenarioProgress) deadlinePassed() bool {
+	return !p.deadline.IsZero() && !time.Now().Before(p.deadline)
+}
+
+func (p *scenarioProgress) targetReached() bool {
+	if p.adaptive == nil || p.adaptive.TargetRSE <= 0 {
+		return false
+	}
+
+	rse, ok := p.relativeStdErr()
+
+	return ok && rse <= p.adaptive.TargetRSE
+}
+
+// relativeStdErr returns the standard error of the mean in percents of the mean.
+func (p *scenarioProgress) relativeStdErr() (float64, bool) {
+	if len(p.samples) < 2 {
+		return 0, false
+	}
+
+	mean, err := stats.Mean(p.samples)
+	if err != nil {
+		return 0, false
+	}
+	if mean == 0 {
+		return 0, true
+	}
+
+	stdDev, err := stats.StandardDeviationSample(p.samples)
+	if err != nil {
+		return 0, false
+	}
+
+	return stdDev / math.Sqrt(float64(len(p.samples))) / mean * 100, true
+}
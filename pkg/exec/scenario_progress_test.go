@@ -0,0 +1,141 @@
+package exec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenarioProgressWithFixedExecutions(t *testing.T) {
+	progress := newScenarioProgress(api.BenchmarkSpec{Executions: 2}, api.ScenarioSpec{})
+
+	assert.False(t, progress.done())
+	assert.Equal(t, 2, progress.expectedExecutions())
+
+	recordDurations(progress, 1, 1)
+
+	assert.True(t, progress.done())
+	assert.Equal(t, 2, progress.expectedExecutions())
+}
+
+func TestScenarioProgressStopsWhenTargetIsReached(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(3, 100, 2, 0), api.ScenarioSpec{})
+
+	recordDurations(progress, 100, 100)
+	assert.False(t, progress.done(), "min executions are expected to be respected")
+
+	recordDurations(progress, 101)
+	assert.True(t, progress.done())
+	assert.Equal(t, 3, progress.expectedExecutions())
+}
+
+func TestScenarioProgressStopsAtMaxExecutions(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(2, 4, 0.001, 0), api.ScenarioSpec{})
+
+	recordDurations(progress, 10, 100, 10)
+	assert.False(t, progress.done())
+
+	recordDurations(progress, 100)
+	assert.True(t, progress.done())
+}
+
+func TestScenarioProgressStopsWhenBudgetIsSpent(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(2, 100, 0, time.Millisecond), api.ScenarioSpec{})
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Millisecond}, time.Millisecond)
+	assert.False(t, progress.done())
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Millisecond}, time.Millisecond)
+	assert.True(t, progress.done())
+}
+
+func TestScenarioProgressExpectedExecutions(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(2, 1000, 1, 0), api.ScenarioSpec{})
+
+	// mean=100, sample stddev=10 => RSE=10/sqrt(2)/100=7.07%. n = 2 * (7.07/1)^2 = 100
+	recordDurations(progress, 90, 110, 100)
+
+	expected := progress.expectedExecutions()
+	assert.Greater(t, expected, 3)
+	assert.Less(t, expected, 1000)
+}
+
+func TestScenarioProgressExpectedExecutionsWithBudget(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(2, 1000, 0, 10*time.Second), api.ScenarioSpec{})
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Second}, time.Second)
+
+	assert.Equal(t, 10, progress.expectedExecutions())
+}
+
+func TestScenarioProgressWithScenarioExecutions(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(2, 100, 0, 0), api.ScenarioSpec{Executions: 3})
+
+	assert.Equal(t, 3, progress.expectedExecutions())
+
+	recordDurations(progress, 1, 1)
+	assert.False(t, progress.done(), "scenario executions are expected to take precedence over adaptive execution")
+
+	recordDurations(progress, 1)
+	assert.True(t, progress.done())
+}
+
+func TestScenarioProgressStopsWhenScenarioBudgetIsSpent(t *testing.T) {
+	progress := newScenarioProgress(api.BenchmarkSpec{Executions: 100}, api.ScenarioSpec{MaxDuration: api.Duration(10 * time.Second)})
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Second}, 4*time.Second)
+	assert.False(t, progress.done())
+	assert.Equal(t, 3, progress.expectedExecutions())
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Second}, 6*time.Second)
+	assert.True(t, progress.done())
+	assert.Equal(t, 2, progress.expectedExecutions())
+}
+
+func TestScenarioProgressScenarioBudgetOverridesAdaptiveBudget(t *testing.T) {
+	progress := newScenarioProgress(anAdaptiveSpec(1, 100, 0, time.Hour), api.ScenarioSpec{MaxDuration: api.Duration(time.Second)})
+
+	progress.record(&api.ExecutionInfo{PerceivedTime: time.Second}, time.Second)
+
+	assert.True(t, progress.done())
+}
+
+func TestScenarioProgressStopsAtDeadline(t *testing.T) {
+	progress := newScenarioProgress(api.BenchmarkSpec{Executions: 100}, api.ScenarioSpec{})
+	progress.deadline = time.Now().Add(-time.Second)
+
+	_, ok := progress.start()
+
+	assert.False(t, ok)
+	assert.True(t, progress.done())
+	assert.True(t, progress.stoppedByDeadline())
+	assert.False(t, progress.finish(), "a scenario that hasn't been started is not expected to be torn down")
+}
+
+func TestScenarioProgressCompleteBeforeDeadlineIsNotStoppedByIt(t *testing.T) {
+	progress := newScenarioProgress(api.BenchmarkSpec{Executions: 1}, api.ScenarioSpec{})
+	progress.deadline = time.Now().Add(-time.Second)
+
+	recordDurations(progress, 1)
+
+	assert.False(t, progress.stoppedByDeadline())
+}
+
+func recordDurations(progress *scenarioProgress, durations ...int) {
+	for _, d := range durations {
+		progress.record(&api.ExecutionInfo{PerceivedTime: time.Duration(d)}, time.Duration(d))
+	}
+}
+
+func anAdaptiveSpec(minExecutions, maxExecutions int, targetRSE float64, maxDuration time.Duration) api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Adaptive: &api.AdaptiveSpec{
+			MinExecutions: minExecutions,
+			MaxExecutions: maxExecutions,
+			TargetRSE:     targetRSE,
+			MaxDuration:   api.Duration(maxDuration),
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"github.com/sha1n/bert/api"
+)
+
+// shellOverheadSamples the number of empty shell executions the startup overhead of a shell is measured with
+const shellOverheadSamples = 20
+
+// shellOverheadExecutor a command executor that subtracts the startup overhead of shells from the execution info
+// of commands that run in a shell.
+type shellOverheadExecutor struct {
+	delegate  api.CommandExecutor
+	overheads map[api.Shell]api.ExecutionInfo
+}
+
+// withShellOverheadSubtraction measures the startup overhead of every shell used by the benchmarked commands of the specified spec,
+// and returns a copy of the specified execution context that subtracts it from their execution info.
+// Shells whose overhead cannot be measured are left as is.
+func withShellOverheadSubtraction(ctx context.Context, spec api.BenchmarkSpec, execCtx api.ExecutionContext) api.ExecutionContext {
+	overheads := map[api.Shell]api.ExecutionInfo{}
+	for _, scenario := range spec.Scenarios {
+		shell := scenario.Command.Shell
+		if _, measured := overheads[shell]; shell == "" || measured {
+			continue
+		}
+
+		overhead, err := measureShellOverhead(ctx, shell, execCtx.Executor)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Failed to measure the startup overhead of '%s': %s", shell, err.Error()))
+			continue
+		}
+
+		slog.Info(fmt.Sprintf("Measured the startup overhead of '%s': %s", shell, overhead.PerceivedTime))
+		overheads[shell] = overhead
+	}
+
+	execCtx.Executor = &shellOverheadExecutor{
+		delegate:  execCtx.Executor,
+		overheads: overheads,
+	}
+
+	return execCtx
+}
+
+// measureShellOverhead returns the median perceived, user and system time of executing an empty command in the specified shell.
+func measureShellOverhead(ctx context.Context, shell api.Shell, executor api.CommandExecutor) (overhead api.ExecutionInfo, err error) {
+	emptyCommand := &api.CommandSpec{Cmd: []string{":"}, Shell: shell}
+	perceived, user, system := stats.Float64Data{}, stats.Float64Data{}, stats.Float64Data{}
+
+	// the first execution is a warmup execution
+	for i := 0; i <= shellOverheadSamples; i++ {
+		if err = ctx.Err(); err != nil {
+			return overhead, err
+		}
+
+		var info *api.ExecutionInfo
+		if info, err = executor.ExecuteFn(ctx, emptyCommand, "", nil)(); err != nil {
+			return overhead, err
+		}
+		if i > 0 && info != nil {
+			perceived = append(perceived, float64(info.PerceivedTime))
+			user = append(user, float64(info.UserTime))
+			system = append(system, float64(info.SystemTime))
+		}
+	}
+
+	if overhead.PerceivedTime, err = nanosStatOf(perceived, stats.Median); err != nil {
+		return overhead, err
+	}
+	if overhead.UserTime, err = nanosStatOf(user, stats.Median); err != nil {
+		return overhead, err
+	}
+	overhead.SystemTime, err = nanosStatOf(system, stats.Median)
+
+	return overhead, err
+}
+
+func (e *shellOverheadExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	execFn := e.delegate.ExecuteFn(ctx, cmd, defaultWorkingDir, env)
+	overhead, ok := e.overheads[cmd.Shell]
+	if cmd.Shell == "" || !ok {
+		return execFn
+	}
+
+	return func() (*api.ExecutionInfo, error) {
+		info, err := execFn()
+		if info != nil {
+			infoCopy := *info
+			infoCopy.PerceivedTime = subtractOverhead(info.PerceivedTime, overhead.PerceivedTime)
+			infoCopy.UserTime = subtractOverhead(info.UserTime, overhead.UserTime)
+			infoCopy.SystemTime = subtractOverhead(info.SystemTime, overhead.SystemTime)
+			info = &infoCopy
+		}
+
+		return info, err
+	}
+}
+
+func subtractOverhead(value time.Duration, overhead time.Duration) time.Duration {
+	return max(0, value-overhead)
+}
@@ -0,0 +1,95 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellOverheadIsSubtractedFromShellCommands(t *testing.T) {
+	executor := &fixedDurationExecutor{overhead: time.Millisecond, command: 10 * time.Millisecond}
+	spec := aShellSpec(api.DefaultShell)
+
+	execCtx := withShellOverheadSubtraction(context.Background(), spec, api.ExecutionContext{Executor: executor})
+	info, err := execCtx.Executor.ExecuteFn(context.Background(), spec.Scenarios[0].Command, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9*time.Millisecond, info.PerceivedTime)
+	assert.Equal(t, 4*time.Millisecond, info.UserTime)
+	assert.Equal(t, time.Duration(0), info.SystemTime, "subtracted values are expected to be clamped at zero")
+	assert.Equal(t, shellOverheadSamples+1, executor.emptyCommandExecutions, "the overhead is expected to be measured once per shell")
+}
+
+func TestShellOverheadIsNotSubtractedFromOtherCommands(t *testing.T) {
+	executor := &fixedDurationExecutor{overhead: time.Millisecond, command: 10 * time.Millisecond}
+	spec := aShellSpec(api.DefaultShell)
+	directCommand := &api.CommandSpec{Cmd: []string{"cmd"}}
+
+	execCtx := withShellOverheadSubtraction(context.Background(), spec, api.ExecutionContext{Executor: executor})
+	info, err := execCtx.Executor.ExecuteFn(context.Background(), directCommand, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, info.PerceivedTime)
+}
+
+func TestShellOverheadIsNotSubtractedIfItCannotBeMeasured(t *testing.T) {
+	executor := &fixedDurationExecutor{overhead: time.Millisecond, command: 10 * time.Millisecond, emptyCommandErr: errors.New("no such shell")}
+	spec := aShellSpec("no-such-shell")
+
+	execCtx := withShellOverheadSubtraction(context.Background(), spec, api.ExecutionContext{Executor: executor})
+	info, err := execCtx.Executor.ExecuteFn(context.Background(), spec.Scenarios[0].Command, "", nil)()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, info.PerceivedTime)
+}
+
+func TestExecuteWithShellOverheadSubtraction(t *testing.T) {
+	executor := &fixedDurationExecutor{overhead: time.Millisecond, command: 10 * time.Millisecond}
+	spec := aShellSpec(api.DefaultShell)
+	spec.Executions = 3
+	spec.SubtractShellOverhead = true
+	tracer := NewTracer(len(spec.Scenarios) * spec.Executions)
+
+	Execute(context.Background(), spec, api.NewExecutionContext(tracer, executor, ui.NewLoggingProgressListener()))
+
+	for i := 0; i < len(spec.Scenarios)*spec.Executions; i++ {
+		trace := <-tracer.Stream()
+		assert.Equal(t, 9*time.Millisecond, trace.PerceivedTime())
+	}
+}
+
+func aShellSpec(shell api.Shell) api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Executions: 1,
+		Scenarios: []api.ScenarioSpec{
+			{Name: "a", Command: &api.CommandSpec{Cmd: []string{"echo | cat"}, Shell: shell}},
+			{Name: "b", Command: &api.CommandSpec{Cmd: []string{"echo | cat"}, Shell: shell}},
+		},
+	}
+}
+
+// fixedDurationExecutor an executor that reports fixed durations, one for empty commands and one for any other command
+type fixedDurationExecutor struct {
+	overhead               time.Duration
+	command                time.Duration
+	emptyCommandErr        error
+	emptyCommandExecutions int
+}
+
+func (e *fixedDurationExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	if cmd.Cmd[0] == ":" {
+		e.emptyCommandExecutions++
+		return func() (*api.ExecutionInfo, error) {
+			return &api.ExecutionInfo{PerceivedTime: e.overhead, UserTime: e.overhead, SystemTime: e.overhead}, e.emptyCommandErr
+		}
+	}
+
+	return func() (*api.ExecutionInfo, error) {
+		return &api.ExecutionInfo{PerceivedTime: e.command, UserTime: e.command / 2, SystemTime: 0}, nil
+	}
+}
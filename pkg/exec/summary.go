@@ -1,18 +1,32 @@
 package exec
 
 import (
+	"errors"
+	"math"
 	"time"
 
 	"github.com/montanaflynn/stats"
 	"github.com/sha1n/bert/api"
 )
 
+// SummaryOptions options that control how summary statistics are calculated
+type SummaryOptions struct {
+	// RemoveOutliers whether to exclude outliers from the mean, stddev and percentile statistics
+	RemoveOutliers bool
+}
+
 // NewSummary create a new TracerSummary with the specified data.
 func NewSummary(tracesByID map[api.ID][]api.Trace) api.Summary {
+	return NewSummaryWith(tracesByID, SummaryOptions{})
+}
+
+// NewSummaryWith create a new TracerSummary with the specified data and options.
+func NewSummaryWith(tracesByID map[api.ID][]api.Trace, opts SummaryOptions) api.Summary {
 	summary := &_summary{
 		perceivedTimeStats: make(map[api.ID]api.Stats, len(tracesByID)),
 		sysCPUTimeStats:    make(map[api.ID]api.Stats, len(tracesByID)),
 		userCPUTimeStats:   make(map[api.ID]api.Stats, len(tracesByID)),
+		usageStats:         make(map[api.ID]api.UsageStats, len(tracesByID)),
 		time:               time.Now(),
 	}
 
@@ -20,28 +34,27 @@ func NewSummary(tracesByID map[api.ID][]api.Trace) api.Summary {
 		perceivedSamples := make([]float64, len(traces))
 		systemSamples := make([]float64, len(traces))
 		userSamples := make([]float64, len(traces))
-		errorCount := 0
+		usageSamples := make([]api.ResourceUsage, len(traces))
+		errorCount, timeoutCount := 0, 0
 
 		for ti := range traces {
 			perceivedSamples[ti] = float64(traces[ti].PerceivedTime().Nanoseconds())
 			systemSamples[ti] = float64(traces[ti].SystemCPUTime().Nanoseconds())
 			userSamples[ti] = float64(traces[ti].UserCPUTime().Nanoseconds())
+			usageSamples[ti] = traces[ti].ResourceUsage()
 			if traces[ti].Error() != nil {
 				errorCount++
 			}
+			if errors.Is(traces[ti].Error(), api.ErrTimeout) {
+				timeoutCount++
+			}
 		}
 
-		summary.perceivedTimeStats[id] = &_stats{
-			float64Samples: perceivedSamples,
-			errorRate:      float64(errorCount) / float64(len(traces)),
-		}
-		summary.userCPUTimeStats[id] = &_stats{
-			float64Samples: userSamples,
-			errorRate:      0,
-		}
-		summary.sysCPUTimeStats[id] = &_stats{
-			float64Samples: systemSamples,
-			errorRate:      0,
+		summary.perceivedTimeStats[id] = newStats(perceivedSamples, float64(errorCount)/float64(len(traces)), float64(timeoutCount)/float64(len(traces)), opts)
+		summary.userCPUTimeStats[id] = newStats(userSamples, 0, 0, opts)
+		summary.sysCPUTimeStats[id] = newStats(systemSamples, 0, 0, opts)
+		summary.usageStats[id] = &_usageStats{
+			samples: usageSamples,
 		}
 	}
 
@@ -50,7 +63,28 @@ func NewSummary(tracesByID map[api.ID][]api.Trace) api.Summary {
 
 type _stats struct {
 	float64Samples stats.Float64Data
-	errorRate      float64
+	// inliers the samples used to calculate the mean, stddev and percentiles
+	inliers     stats.Float64Data
+	outliers    int
+	errorRate   float64
+	timeoutRate float64
+}
+
+func newStats(samples stats.Float64Data, errorRate float64, timeoutRate float64, opts SummaryOptions) *_stats {
+	inliers, outliers := classifyOutliers(samples)
+	s := &_stats{
+		float64Samples: samples,
+		inliers:        samples,
+		outliers:       len(outliers),
+		errorRate:      errorRate,
+		timeoutRate:    timeoutRate,
+	}
+
+	if opts.RemoveOutliers {
+		s.inliers = inliers
+	}
+
+	return s
 }
 
 func (s *_stats) Min() (duration time.Duration, err error) {
@@ -62,43 +96,143 @@ func (s *_stats) Max() (duration time.Duration, err error) {
 }
 
 func (s *_stats) Mean() (duration time.Duration, err error) {
-	return s.nanosStat(stats.Mean)
+	return s.inliersNanosStat(stats.Mean)
 }
 
 func (s *_stats) StdDev() (duration time.Duration, err error) {
-	return s.nanosStat(stats.StandardDeviation)
+	return s.inliersNanosStat(stats.StandardDeviation)
 }
 
 func (s *_stats) Median() (duration time.Duration, err error) {
-	return s.nanosStat(stats.Median)
+	return s.inliersNanosStat(stats.Median)
 }
 
 func (s *_stats) Percentile(percent float64) (duration time.Duration, err error) {
-	return s.nanosStat(func(data stats.Float64Data) (float64, error) {
+	return s.inliersNanosStat(func(data stats.Float64Data) (float64, error) {
 		return stats.Percentile(data, percent)
 	})
 }
 
+// MeanConfidenceInterval estimates the confidence interval of the mean by bootstrap resampling.
+func (s *_stats) MeanConfidenceInterval(level float64) (time.Duration, time.Duration, error) {
+	return s.inliersNanosConfidenceInterval(stats.Mean, level)
+}
+
+// MedianConfidenceInterval estimates the confidence interval of the median by bootstrap resampling.
+func (s *_stats) MedianConfidenceInterval(level float64) (time.Duration, time.Duration, error) {
+	return s.inliersNanosConfidenceInterval(stats.Median, level)
+}
+
+func (s *_stats) Outliers() int {
+	return s.outliers
+}
+
 func (s *_stats) ErrorRate() float64 {
 	return s.errorRate
 }
 
+func (s *_stats) TimeoutRate() float64 {
+	return s.timeoutRate
+}
+
 func (s *_stats) Count() int {
 	return len(s.float64Samples)
 }
 
+func (s *_stats) Samples() []time.Duration {
+	samples := make([]time.Duration, len(s.inliers))
+	for i, sample := range s.inliers {
+		samples[i] = time.Duration(sample) * time.Nanosecond
+	}
+
+	return samples
+}
+
 func (s *_stats) nanosStat(f func(stats.Float64Data) (float64, error)) (duration time.Duration, err error) {
+	return nanosStatOf(s.float64Samples, f)
+}
+
+func (s *_stats) inliersNanosStat(f func(stats.Float64Data) (float64, error)) (duration time.Duration, err error) {
+	return nanosStatOf(s.inliers, f)
+}
+
+func (s *_stats) inliersNanosConfidenceInterval(f func(stats.Float64Data) (float64, error), level float64) (lower time.Duration, upper time.Duration, err error) {
+	var lowerNanos, upperNanos float64
+	if lowerNanos, upperNanos, err = bootstrapConfidenceInterval(s.inliers, f, level); err == nil {
+		lower, upper = time.Duration(lowerNanos)*time.Nanosecond, time.Duration(upperNanos)*time.Nanosecond
+	}
+
+	return
+}
+
+func nanosStatOf(data stats.Float64Data, f func(stats.Float64Data) (float64, error)) (duration time.Duration, err error) {
 	var nanos float64
-	if nanos, err = f(s.float64Samples); err == nil {
+	if nanos, err = f(data); err == nil {
 		duration = time.Duration(nanos) * time.Nanosecond
 	}
 	return
 }
 
+type _usageStats struct {
+	samples []api.ResourceUsage
+}
+
+func (s *_usageStats) Mean() (usage api.ResourceUsage, err error) {
+	if len(s.samples) == 0 {
+		return usage, stats.ErrEmptyInput
+	}
+
+	var sum api.ResourceUsage
+	for _, sample := range s.samples {
+		sum.MaxRSS += sample.MaxRSS
+		sum.MinorPageFaults += sample.MinorPageFaults
+		sum.MajorPageFaults += sample.MajorPageFaults
+		sum.VoluntaryContextSwitches += sample.VoluntaryContextSwitches
+		sum.InvoluntaryContextSwitches += sample.InvoluntaryContextSwitches
+		sum.BlockInputOps += sample.BlockInputOps
+		sum.BlockOutputOps += sample.BlockOutputOps
+	}
+
+	mean := func(sum int64) int64 {
+		return int64(math.Round(float64(sum) / float64(len(s.samples))))
+	}
+
+	return api.ResourceUsage{
+		MaxRSS:                     mean(sum.MaxRSS),
+		MinorPageFaults:            mean(sum.MinorPageFaults),
+		MajorPageFaults:            mean(sum.MajorPageFaults),
+		VoluntaryContextSwitches:   mean(sum.VoluntaryContextSwitches),
+		InvoluntaryContextSwitches: mean(sum.InvoluntaryContextSwitches),
+		BlockInputOps:              mean(sum.BlockInputOps),
+		BlockOutputOps:             mean(sum.BlockOutputOps),
+	}, nil
+}
+
+func (s *_usageStats) Max() (usage api.ResourceUsage, err error) {
+	if len(s.samples) == 0 {
+		return usage, stats.ErrEmptyInput
+	}
+
+	for _, sample := range s.samples {
+		usage = api.ResourceUsage{
+			MaxRSS:                     max(usage.MaxRSS, sample.MaxRSS),
+			MinorPageFaults:            max(usage.MinorPageFaults, sample.MinorPageFaults),
+			MajorPageFaults:            max(usage.MajorPageFaults, sample.MajorPageFaults),
+			VoluntaryContextSwitches:   max(usage.VoluntaryContextSwitches, sample.VoluntaryContextSwitches),
+			InvoluntaryContextSwitches: max(usage.InvoluntaryContextSwitches, sample.InvoluntaryContextSwitches),
+			BlockInputOps:              max(usage.BlockInputOps, sample.BlockInputOps),
+			BlockOutputOps:             max(usage.BlockOutputOps, sample.BlockOutputOps),
+		}
+	}
+
+	return usage, nil
+}
+
 type _summary struct {
 	perceivedTimeStats map[api.ID]api.Stats
 	sysCPUTimeStats    map[api.ID]api.Stats
 	userCPUTimeStats   map[api.ID]api.Stats
+	usageStats         map[api.ID]api.UsageStats
 	time               time.Time
 }
 
@@ -114,6 +248,14 @@ func (summary *_summary) UserTimeStats(id api.ID) api.Stats {
 	return summary.userCPUTimeStats[id]
 }
 
+func (summary *_summary) ResourceUsageStats(id api.ID) api.UsageStats {
+	return summary.usageStats[id]
+}
+
+func (summary *_summary) PerceivedTimeComparison(id api.ID, baseline api.Stats) api.Comparison {
+	return NewComparison(baseline, summary.PerceivedTimeStats(id))
+}
+
 func (summary *_summary) IDs() []api.ID {
 	ids := make([]api.ID, 0, len(summary.perceivedTimeStats))
 	for k := range summary.perceivedTimeStats {
@@ -2,6 +2,7 @@ package exec
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -22,6 +23,22 @@ func TestErrorRateStat(t *testing.T) {
 	assert.Equal(t, 0.1, singleErrStats.ErrorRate())
 }
 
+func TestTimeoutRateStat(t *testing.T) {
+	traces := map[api.ID][]api.Trace{
+		"a": {
+			aTraceWith("a", 1, nil),
+			aTraceWith("a", 1, errors.New("failed")),
+			aTraceWith("a", 1, fmt.Errorf("command %w", api.ErrTimeout)),
+			aTraceWith("a", 1, fmt.Errorf("command %w", api.ErrTimeout)),
+		},
+	}
+
+	stats := NewSummary(traces).PerceivedTimeStats("a")
+
+	assert.Equal(t, 0.75, stats.ErrorRate())
+	assert.Equal(t, 0.5, stats.TimeoutRate())
+}
+
 func TestCount(t *testing.T) {
 	summary, expectedCount := generateExampleSummary()
 
@@ -37,6 +54,101 @@ func TestCount(t *testing.T) {
 	assertCount(summary.SystemTimeStats(SingleErrScenarioID))
 }
 
+func TestSamples(t *testing.T) {
+	stats := aStatsWith(3, 1, 2)
+
+	assert.Equal(t, []time.Duration{3, 1, 2}, stats.Samples())
+}
+
+func TestOutliers(t *testing.T) {
+	traces := map[api.ID][]api.Trace{"id": tracesWith("id", 10, 11, 12, 11, 10, 12, 11, 100)}
+
+	stats := NewSummary(traces).PerceivedTimeStats("id")
+	mean, _ := stats.Mean()
+
+	assert.Equal(t, 1, stats.Outliers())
+	assert.Equal(t, 8, stats.Count())
+	assert.Equal(t, time.Duration(22), mean, "outliers are expected to be included by default")
+	assert.Equal(t, 8, len(stats.Samples()))
+}
+
+func TestRemoveOutliers(t *testing.T) {
+	traces := map[api.ID][]api.Trace{"id": tracesWith("id", 10, 11, 12, 11, 10, 12, 11, 100)}
+
+	stats := NewSummaryWith(traces, SummaryOptions{RemoveOutliers: true}).PerceivedTimeStats("id")
+	mean, _ := stats.Mean()
+	p90, _ := stats.Percentile(90)
+	maxValue, _ := stats.Max()
+
+	assert.Equal(t, 1, stats.Outliers())
+	assert.Equal(t, 8, stats.Count(), "the count is expected to include outliers")
+	assert.Equal(t, time.Duration(11), mean)
+	assert.Equal(t, time.Duration(12), p90)
+	assert.Equal(t, time.Duration(100), maxValue, "the max is expected to include outliers")
+	assert.Equal(t, 7, len(stats.Samples()))
+}
+
+func TestConfidenceIntervals(t *testing.T) {
+	stats := aStatsWith(10, 11, 12, 11, 10, 12, 11, 13, 9, 11)
+	mean, _ := stats.Mean()
+	median, _ := stats.Median()
+
+	meanLower, meanUpper, err := stats.MeanConfidenceInterval(0.95)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, meanLower, mean)
+	assert.GreaterOrEqual(t, meanUpper, mean)
+
+	medianLower, medianUpper, err := stats.MedianConfidenceInterval(0.95)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, medianLower, median)
+	assert.GreaterOrEqual(t, medianUpper, median)
+}
+
+func TestConfidenceIntervalsWithTooFewSamples(t *testing.T) {
+	stats := aStatsWith(10)
+
+	_, _, err := stats.MeanConfidenceInterval(0.95)
+	assert.Error(t, err)
+	_, _, err = stats.MedianConfidenceInterval(0.95)
+	assert.Error(t, err)
+}
+
+func tracesWith(id string, durations ...int) []api.Trace {
+	traces := []api.Trace{}
+	for _, d := range durations {
+		traces = append(traces, aTraceWith(id, d, nil))
+	}
+
+	return traces
+}
+
+func TestResourceUsageStats(t *testing.T) {
+	summary := NewSummary(map[api.ID][]api.Trace{
+		"id": {
+			&trace{id: "id", resourceUsage: api.ResourceUsage{MaxRSS: 100, MinorPageFaults: 1, VoluntaryContextSwitches: 4, BlockOutputOps: 7}},
+			&trace{id: "id", resourceUsage: api.ResourceUsage{MaxRSS: 300, MinorPageFaults: 2, InvoluntaryContextSwitches: 5, BlockInputOps: 6}},
+		},
+	})
+
+	mean, err := summary.ResourceUsageStats("id").Mean()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ResourceUsage{MaxRSS: 200, MinorPageFaults: 2, VoluntaryContextSwitches: 2, InvoluntaryContextSwitches: 3, BlockInputOps: 3, BlockOutputOps: 4}, mean)
+
+	maxUsage, err := summary.ResourceUsageStats("id").Max()
+	assert.NoError(t, err)
+	assert.Equal(t, api.ResourceUsage{MaxRSS: 300, MinorPageFaults: 2, VoluntaryContextSwitches: 4, InvoluntaryContextSwitches: 5, BlockInputOps: 6, BlockOutputOps: 7}, maxUsage)
+}
+
+func TestResourceUsageStatsWithNoSamples(t *testing.T) {
+	stats := &_usageStats{}
+
+	_, err := stats.Mean()
+	assert.Error(t, err)
+
+	_, err = stats.Max()
+	assert.Error(t, err)
+}
+
 func generateExampleSummary() (api.Summary, int) {
 	size := 10
 	traces := make(map[api.ID][]api.Trace)
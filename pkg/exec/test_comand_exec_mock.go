@@ -2,6 +2,7 @@ package exec
 
 import (
 	"context"
+	"sync"
 
 	"github.com/sha1n/bert/api"
 )
@@ -9,6 +10,7 @@ import (
 // CmdRecordingExecutor ...
 type CmdRecordingExecutor struct {
 	RecordedCommandSeq []*RecordedExecutionParams
+	mx                 sync.Mutex
 }
 
 // RecordedExecutionParams ...
@@ -25,6 +27,8 @@ func (ce *CmdRecordingExecutor) ExecuteFn(
 	defaultWorkingDir string,
 	env map[string]string,
 ) api.ExecCommandFn {
+	ce.mx.Lock()
+	defer ce.mx.Unlock()
 
 	ce.RecordedCommandSeq = append(ce.RecordedCommandSeq, &RecordedExecutionParams{
 		Spec:              cmdSpec,
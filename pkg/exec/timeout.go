@@ -0,0 +1,44 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"github.com/sha1n/bert/api"
+)
+
+// defaultTimeoutExecutor a command executor that applies a default timeout to commands that don't specify one.
+type defaultTimeoutExecutor struct {
+	delegate api.CommandExecutor
+	timeout  api.Duration
+}
+
+// withDefaultTimeout returns a copy of the specified execution context that applies the specified timeout
+// to commands that don't specify one.
+func withDefaultTimeout(timeout api.Duration, execCtx api.ExecutionContext) api.ExecutionContext {
+	execCtx.Executor = &defaultTimeoutExecutor{
+		delegate: execCtx.Executor,
+		timeout:  timeout,
+	}
+
+	return execCtx
+}
+
+func (e *defaultTimeoutExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	if cmd.Timeout == 0 {
+		cmdCopy := *cmd
+		cmdCopy.Timeout = e.timeout
+		cmd = &cmdCopy
+	}
+
+	return e.delegate.ExecuteFn(ctx, cmd, defaultWorkingDir, env)
+}
+
+// withTimeout returns a context that is done when the specified timeout elapses. A non-positive timeout means no timeout.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
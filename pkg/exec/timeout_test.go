@@ -0,0 +1,58 @@
+package exec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTimeoutIsAppliedToCommandsWithoutTimeout(t *testing.T) {
+	recorder := &CmdRecordingExecutor{}
+	cmd := &api.CommandSpec{Cmd: []string{"cmd"}}
+
+	execCtx := withDefaultTimeout(api.Duration(time.Second), api.ExecutionContext{Executor: recorder})
+	_, _ = execCtx.Executor.ExecuteFn(context.Background(), cmd, "", nil)()
+
+	assert.Equal(t, api.Duration(time.Second), recorder.RecordedCommandSeq[0].Spec.Timeout)
+	assert.Equal(t, api.Duration(0), cmd.Timeout, "the original spec is not expected to be modified")
+}
+
+func TestDefaultTimeoutDoesNotOverrideCommandTimeout(t *testing.T) {
+	recorder := &CmdRecordingExecutor{}
+	cmd := &api.CommandSpec{Cmd: []string{"cmd"}, Timeout: api.Duration(time.Minute)}
+
+	execCtx := withDefaultTimeout(api.Duration(time.Second), api.ExecutionContext{Executor: recorder})
+	_, _ = execCtx.Executor.ExecuteFn(context.Background(), cmd, "", nil)()
+
+	assert.Equal(t, api.Duration(time.Minute), recorder.RecordedCommandSeq[0].Spec.Timeout)
+}
+
+func TestExecuteWithDefaultTimeout(t *testing.T) {
+	recorder := &CmdRecordingExecutor{}
+	spec := aShellSpec("")
+	spec.Timeout = api.Duration(time.Second)
+	tracer := NewTracer(len(spec.Scenarios) * spec.Executions)
+
+	Execute(context.Background(), spec, api.NewExecutionContext(tracer, recorder, ui.NewLoggingProgressListener()))
+
+	assert.Equal(t, len(spec.Scenarios), len(recorder.RecordedCommandSeq))
+	for _, params := range recorder.RecordedCommandSeq {
+		assert.Equal(t, spec.Timeout, params.Spec.Timeout)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	assert.True(t, hasDeadline)
+
+	ctx, cancel = withTimeout(context.Background(), 0)
+	defer cancel()
+	_, hasDeadline = ctx.Deadline()
+	assert.False(t, hasDeadline)
+}
@@ -15,6 +15,9 @@ type trace struct {
 	perceivedTime time.Duration
 	usrCPUTime    time.Duration
 	sysCPUTime    time.Duration
+	resourceUsage api.ResourceUsage
+	concurrency   int
+	wait          time.Duration
 	error         error
 }
 
@@ -34,6 +37,18 @@ func (t trace) UserCPUTime() time.Duration {
 	return t.usrCPUTime
 }
 
+func (t trace) ResourceUsage() api.ResourceUsage {
+	return t.resourceUsage
+}
+
+func (t trace) Concurrency() int {
+	return t.concurrency
+}
+
+func (t trace) Wait() time.Duration {
+	return t.wait
+}
+
 func (t trace) Error() error {
 	return t.error
 }
@@ -61,6 +76,9 @@ func (tr *tracer) endFn(t trace) api.End {
 	return func(execInfo *api.ExecutionInfo, exitError error) {
 		if execInfo != nil {
 			t.perceivedTime, t.usrCPUTime, t.sysCPUTime = execInfo.PerceivedTime, execInfo.UserTime, execInfo.SystemTime
+			t.resourceUsage = execInfo.ResourceUsage
+			t.concurrency = execInfo.Concurrency
+			t.wait = execInfo.Wait
 		}
 		t.error = exitError
 
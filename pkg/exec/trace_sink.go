@@ -32,10 +32,28 @@ func (s *TraceSink) Subscribe() Unsubscribe {
 
 // Summary returns an Summary object containing stats about accumulated events so far.
 func (s *TraceSink) Summary() api.Summary {
+	return s.SummaryWith(SummaryOptions{})
+}
+
+// SummaryWith returns an Summary object containing stats about accumulated events so far, calculated with the specified options.
+func (s *TraceSink) SummaryWith(opts SummaryOptions) api.Summary {
 	s.mx.RLock()
 	defer s.mx.RUnlock()
 
-	return NewSummary(s.traces)
+	return NewSummaryWith(s.traces, opts)
+}
+
+// Traces returns a copy of the accumulated traces grouped by ID.
+func (s *TraceSink) Traces() map[api.ID][]api.Trace {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	traces := make(map[api.ID][]api.Trace, len(s.traces))
+	for id := range s.traces {
+		traces[id] = append([]api.Trace{}, s.traces[id]...)
+	}
+
+	return traces
 }
 
 func (s *TraceSink) add(trace api.Trace) error {
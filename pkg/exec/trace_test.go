@@ -17,6 +17,8 @@ func Test_tracer_endFn(t *testing.T) {
 	expectedSysTime := time.Duration(gommonstest.RandomUint())
 	expectedPerceivedTime := time.Duration(gommonstest.RandomUint())
 	expectedExitCode := int(gommonstest.RandomUint())
+	expectedUsage := api.ResourceUsage{MaxRSS: int64(gommonstest.RandomUint()), MajorPageFaults: int64(gommonstest.RandomUint())}
+	expectedWait := time.Duration(gommonstest.RandomUint())
 	var expectedError error
 
 	tracer := NewTracer(1)
@@ -27,6 +29,8 @@ func Test_tracer_endFn(t *testing.T) {
 			SystemTime:    time.Duration(expectedSysTime),
 			PerceivedTime: time.Duration(expectedPerceivedTime),
 			ExitCode:      expectedExitCode,
+			ResourceUsage: expectedUsage,
+			Wait:          expectedWait,
 		},
 		expectedError,
 	)
@@ -35,6 +39,8 @@ func Test_tracer_endFn(t *testing.T) {
 	assert.Equal(t, expectedPerceivedTime, received.PerceivedTime())
 	assert.Equal(t, expectedUserTime, received.UserCPUTime())
 	assert.Equal(t, expectedSysTime, received.SystemCPUTime())
+	assert.Equal(t, expectedUsage, received.ResourceUsage())
+	assert.Equal(t, expectedWait, received.Wait())
 	assert.Equal(t, expectedError, received.Error())
 	assert.Equal(t, expectedID, received.ID())
 }
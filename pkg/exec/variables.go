@@ -0,0 +1,109 @@
+package exec
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/sha1n/bert/api"
+)
+
+const (
+	// VarScenario the name of the built-in variable that holds the ID of the scenario a command runs for
+	VarScenario = "BERT_SCENARIO"
+	// VarRunIndex the name of the built-in variable that holds the 1-based index of the current run, or 0 outside of measured runs
+	VarRunIndex = "BERT_RUN_INDEX"
+	// VarRunTotal the name of the built-in variable that holds the max number of runs of the current scenario
+	VarRunTotal = "BERT_RUN_TOTAL"
+	// VarOutputDir the name of the built-in variable that holds the absolute path of the output directory of the benchmark
+	VarOutputDir = "BERT_OUTPUT_DIR"
+)
+
+// variableRefPattern matches '${name}' references and '$${' escape sequences
+var variableRefPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// variablesExecutor a command executor that interpolates variable references in commands and passes the built-in
+// variables of the current run to commands as environment variables.
+type variablesExecutor struct {
+	delegate  api.CommandExecutor
+	vars      map[string]string
+	outputDir string
+}
+
+// withVariables returns a copy of the specified execution context that interpolates '${name}' references in the command
+// lines, environment variables and working directories of commands. References are resolved from the built-in variables,
+// the variables of the spec and the OS environment, in this order. Unresolved references are left as is.
+func withVariables(spec api.BenchmarkSpec, execCtx api.ExecutionContext) api.ExecutionContext {
+	outputDir, err := filepath.Abs(spec.OutputDir)
+	if err != nil {
+		outputDir = spec.OutputDir
+	}
+
+	execCtx.Executor = &variablesExecutor{
+		delegate:  execCtx.Executor,
+		vars:      spec.Vars,
+		outputDir: outputDir,
+	}
+
+	return execCtx
+}
+
+func (e *variablesExecutor) ExecuteFn(ctx context.Context, cmd *api.CommandSpec, defaultWorkingDir string, env map[string]string) api.ExecCommandFn {
+	builtins := e.builtinsOf(ctx)
+	interpolate := func(s string) string {
+		return interpolateVariables(s, func(name string) (string, bool) {
+			if value, ok := builtins[name]; ok {
+				return value, true
+			}
+			if value, ok := e.vars[name]; ok {
+				return value, true
+			}
+			return os.LookupEnv(name)
+		})
+	}
+
+	cmdCopy := *cmd
+	cmdCopy.WorkingDirectory = interpolate(cmd.WorkingDirectory)
+	cmdCopy.Stdin = interpolate(cmd.Stdin)
+	cmdCopy.Cmd = make([]string, len(cmd.Cmd))
+	for i, arg := range cmd.Cmd {
+		cmdCopy.Cmd[i] = interpolate(arg)
+	}
+
+	cmdEnv := make(map[string]string, len(env)+len(builtins))
+	for name, value := range env {
+		cmdEnv[name] = interpolate(value)
+	}
+	maps.Copy(cmdEnv, builtins)
+
+	return e.delegate.ExecuteFn(ctx, &cmdCopy, interpolate(defaultWorkingDir), cmdEnv)
+}
+
+func (e *variablesExecutor) builtinsOf(ctx context.Context) map[string]string {
+	builtins := map[string]string{VarOutputDir: e.outputDir}
+	if run, ok := api.RunContextOf(ctx); ok {
+		builtins[VarScenario] = run.Scenario
+		builtins[VarRunIndex] = strconv.Itoa(run.Index)
+		builtins[VarRunTotal] = strconv.Itoa(run.Total)
+	}
+
+	return builtins
+}
+
+// interpolateVariables replaces '${name}' references in the specified string with the values returned by the specified
+// lookup function. References the function doesn't resolve are left as is, and '$${' is replaced with a literal '${'.
+func interpolateVariables(s string, lookup func(name string) (string, bool)) string {
+	return variableRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		if value, ok := lookup(ref[2 : len(ref)-1]); ok {
+			return value
+		}
+
+		return ref
+	})
+}
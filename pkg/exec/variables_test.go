@@ -0,0 +1,87 @@
+package exec
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolateVariables(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"A": "a", "B_2": "b"}[name]
+		return value, ok
+	}
+
+	assert.Equal(t, "a-b", interpolateVariables("${A}-${B_2}", lookup))
+	assert.Equal(t, "a ${UNDEFINED} $A", interpolateVariables("${A} ${UNDEFINED} $A", lookup))
+	assert.Equal(t, "${A} a", interpolateVariables("$${A} ${A}", lookup))
+	assert.Equal(t, "${not a name}", interpolateVariables("${not a name}", lookup))
+}
+
+func TestExecuteFnWithVariables(t *testing.T) {
+	t.Setenv("BERT_TEST_OS_VAR", "os")
+	t.Setenv("BERT_TEST_SHADOWED_VAR", "os")
+
+	spec := api.BenchmarkSpec{
+		Vars:      map[string]string{"BERT_TEST_SHADOWED_VAR": "spec", VarRunIndex: "spec"},
+		OutputDir: "out",
+	}
+	recorder := &CmdRecordingExecutor{}
+	executor := withVariables(spec, api.ExecutionContext{Executor: recorder}).Executor
+	ctx := api.WithRunContext(context.Background(), api.RunContext{Scenario: "scenario", Index: 3, Total: 10})
+	cmd := &api.CommandSpec{
+		WorkingDirectory: "/runs/${BERT_RUN_INDEX}",
+		Cmd:              []string{"cmd", "${BERT_TEST_OS_VAR}", "${BERT_TEST_SHADOWED_VAR}", "${BERT_SCENARIO}-${BERT_RUN_INDEX}-of-${BERT_RUN_TOTAL}"},
+	}
+
+	_, _ = executor.ExecuteFn(ctx, cmd, "/${BERT_SCENARIO}", map[string]string{"RUN_DIR": "${BERT_OUTPUT_DIR}/${BERT_RUN_INDEX}"})()
+
+	expectedOutputDir, _ := filepath.Abs("out")
+	recorded := recorder.RecordedCommandSeq[0]
+	assert.Equal(t, []string{"cmd", "os", "spec", "scenario-3-of-10"}, recorded.Spec.Cmd)
+	assert.Equal(t, "/runs/3", recorded.Spec.WorkingDirectory)
+	assert.Equal(t, "/scenario", recorded.DefaultWorkingDir)
+	assert.Equal(t, map[string]string{
+		"RUN_DIR":    expectedOutputDir + "/3",
+		VarScenario:  "scenario",
+		VarRunIndex:  "3",
+		VarRunTotal:  "10",
+		VarOutputDir: expectedOutputDir,
+	}, recorded.Env)
+	assert.Equal(t, "${BERT_TEST_OS_VAR}", cmd.Cmd[1], "the original command is not expected to be modified")
+}
+
+func TestExecuteFnWithVariablesOutsideOfScenarioRuns(t *testing.T) {
+	recorder := &CmdRecordingExecutor{}
+	executor := withVariables(api.BenchmarkSpec{}, api.ExecutionContext{Executor: recorder}).Executor
+
+	_, _ = executor.ExecuteFn(context.Background(), &api.CommandSpec{Cmd: []string{"${BERT_RUN_INDEX}"}}, "", nil)()
+
+	recorded := recorder.RecordedCommandSeq[0]
+	assert.Equal(t, []string{"${BERT_RUN_INDEX}"}, recorded.Spec.Cmd)
+	assert.Contains(t, recorded.Env, VarOutputDir)
+	assert.NotContains(t, recorded.Env, VarRunIndex)
+}
+
+func TestExecuteBenchmarkPassesRunVariablesToCommands(t *testing.T) {
+	spec := aSpecWithSetupAndTeardownCommands(2)
+	spec.Warmup = 1
+
+	recorded := executeWith(spec).RecordedCommandSeq
+
+	runIndexes := []string{}
+	for _, params := range recorded {
+		assert.Equal(t, "2", params.Env[VarRunTotal])
+		runIndexes = append(runIndexes, params.Env[VarRunIndex])
+	}
+	assert.Equal(t, []string{
+		"0",           // beforeAll
+		"0", "0", "0", // warmup
+		"1", "1", "1", // run 1
+		"2", "2", "2", // run 2
+		"0", // afterAll
+	}, runIndexes)
+}
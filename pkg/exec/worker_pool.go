@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"context"
+	"sync"
+)
+
+// scheduleFn returns the next scenario run to start, or false if no run can be started at the moment.
+type scheduleFn = func() (progress *scenarioProgress, execIndex int, ok bool)
+
+// runFn executes a scheduled scenario run. The concurrency is the number of runs in progress, including this one.
+type runFn = func(progress *scenarioProgress, execIndex int, concurrency int)
+
+// workerPool executes scheduled scenario runs on a bounded number of workers.
+//
+// One of the workers always runs on the calling goroutine, so a pool with a concurrency of 1 executes everything
+// in order on the calling goroutine. Panics raised by any of the workers stop the pool and are re-raised on the
+// calling goroutine once all workers are done.
+type workerPool struct {
+	concurrency int
+	mx          *sync.Mutex
+	cond        *sync.Cond
+	inFlight    int
+	panicValue  interface{}
+}
+
+func newWorkerPool(concurrency int) *workerPool {
+	mx := &sync.Mutex{}
+
+	return &workerPool{
+		concurrency: max(concurrency, 1),
+		mx:          mx,
+		cond:        sync.NewCond(mx),
+	}
+}
+
+// run runs scheduled scenario runs until no more runs can be scheduled and no runs are in progress,
+// or until the specified context is done.
+func (p *workerPool) run(ctx context.Context, schedule scheduleFn, run runFn) {
+	wg := &sync.WaitGroup{}
+	for i := 1; i < p.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx, schedule, run)
+		}()
+	}
+
+	p.work(ctx, schedule, run)
+	wg.Wait()
+
+	if p.panicValue != nil {
+		panic(p.panicValue)
+	}
+}
+
+func (p *workerPool) work(ctx context.Context, schedule scheduleFn, run runFn) {
+	defer p.recoverPanic()
+
+	for {
+		progress, execIndex, concurrency, ok := p.next(ctx, schedule)
+		if !ok {
+			return
+		}
+
+		run(progress, execIndex, concurrency)
+
+		p.mx.Lock()
+		p.inFlight--
+		p.cond.Broadcast()
+		p.mx.Unlock()
+	}
+}
+
+// next blocks until a run can be scheduled, or until there is nothing left to do.
+func (p *workerPool) next(ctx context.Context, schedule scheduleFn) (*scenarioProgress, int, int, bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	for {
+		if ctx.Err() != nil || p.panicValue != nil {
+			return nil, 0, 0, false
+		}
+		if progress, execIndex, ok := schedule(); ok {
+			p.inFlight++
+			return progress, execIndex, p.inFlight, true
+		}
+		// runs in progress might not complete a scenario, in which case more runs are going to be scheduled
+		if p.inFlight == 0 {
+			return nil, 0, 0, false
+		}
+
+		p.cond.Wait()
+	}
+}
+
+func (p *workerPool) recoverPanic() {
+	if o := recover(); o != nil {
+		p.mx.Lock()
+		defer p.mx.Unlock()
+
+		if p.panicValue == nil {
+			p.panicValue = o
+		}
+		p.inFlight--
+		p.cond.Broadcast()
+	}
+}
@@ -0,0 +1,92 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolRespectsConcurrencyLimit(t *testing.T) {
+	spec := aBasicSpecWith(false, 20)
+	progress := newScenarioProgress(spec, spec.Scenarios[0])
+	mx := &sync.Mutex{}
+	running, maxRunning := 0, 0
+	maxConcurrency := 0
+
+	newWorkerPool(4).run(context.Background(), scheduleOf(progress), func(p *scenarioProgress, execIndex int, concurrency int) {
+		mx.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		maxConcurrency = max(maxConcurrency, concurrency)
+		mx.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mx.Lock()
+		running--
+		mx.Unlock()
+
+		p.record(&api.ExecutionInfo{}, time.Millisecond)
+	})
+
+	assert.True(t, progress.done())
+	assert.Equal(t, 20, progress.executions)
+	assert.LessOrEqual(t, maxRunning, 4)
+	assert.LessOrEqual(t, maxConcurrency, 4)
+	assert.Greater(t, maxConcurrency, 1)
+}
+
+func TestWorkerPoolWithConcurrencyOneRunsInOrder(t *testing.T) {
+	spec := aBasicSpecWith(false, 5)
+	progress := newScenarioProgress(spec, spec.Scenarios[0])
+	indices := []int{}
+
+	newWorkerPool(0).run(context.Background(), scheduleOf(progress), func(p *scenarioProgress, execIndex int, concurrency int) {
+		assert.Equal(t, 1, concurrency)
+		indices = append(indices, execIndex)
+		p.record(&api.ExecutionInfo{}, time.Millisecond)
+	})
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, indices)
+}
+
+func TestWorkerPoolStopsWhenContextIsDone(t *testing.T) {
+	spec := aBasicSpecWith(false, 10)
+	progress := newScenarioProgress(spec, spec.Scenarios[0])
+	ctx, cancel := context.WithCancel(context.Background())
+
+	newWorkerPool(2).run(ctx, scheduleOf(progress), func(p *scenarioProgress, execIndex int, concurrency int) {
+		cancel()
+		p.record(&api.ExecutionInfo{}, time.Millisecond)
+	})
+
+	assert.Less(t, progress.executions, 10)
+}
+
+func TestWorkerPoolPropagatesPanics(t *testing.T) {
+	spec := aBasicSpecWith(false, 10)
+	progress := newScenarioProgress(spec, spec.Scenarios[0])
+	expectedErr := errors.New("boom")
+
+	assert.PanicsWithValue(t, expectedErr, func() {
+		newWorkerPool(3).run(context.Background(), scheduleOf(progress), func(p *scenarioProgress, execIndex int, concurrency int) {
+			defer p.record(&api.ExecutionInfo{}, time.Millisecond)
+
+			if execIndex == 2 {
+				panic(expectedErr)
+			}
+		})
+	})
+}
+
+func scheduleOf(progress *scenarioProgress) scheduleFn {
+	return func() (*scenarioProgress, int, bool) {
+		execIndex, ok := progress.start()
+		return progress, execIndex, ok
+	}
+}
@@ -0,0 +1,26 @@
+package osutil
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/sha1n/bert/api"
+)
+
+// CurrentHost returns information about the current host, including its current load average.
+// Values that are not available on the current platform are left empty.
+func CurrentHost() api.HostInfo {
+	hostname, _ := os.Hostname()
+	loadAverage, _ := LoadAverage()
+
+	host := api.HostInfo{
+		Hostname:    hostname,
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		CPUs:        runtime.NumCPU(),
+		LoadAverage: loadAverage,
+	}
+	addPlatformHostInfo(&host)
+
+	return host
+}
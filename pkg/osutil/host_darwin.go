@@ -0,0 +1,38 @@
+package osutil
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/sha1n/bert/api"
+	"golang.org/x/sys/unix"
+)
+
+// LoadAverage returns the 1, 5 and 15 minute load averages of the current host.
+func LoadAverage() ([]float64, error) {
+	// struct loadavg { fixpt_t ldavg[3]; long fscale; }
+	raw, err := unix.SysctlRaw("vm.loadavg")
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < 24 {
+		return nil, errors.New("unexpected 'vm.loadavg' value")
+	}
+
+	scale := float64(binary.LittleEndian.Uint64(raw[16:24]))
+	loadAverage := make([]float64, 3)
+	for i := range loadAverage {
+		loadAverage[i] = float64(binary.LittleEndian.Uint32(raw[i*4:])) / scale
+	}
+
+	return loadAverage, nil
+}
+
+func addPlatformHostInfo(host *api.HostInfo) {
+	var uname unix.Utsname
+	if err := unix.Uname(&uname); err == nil {
+		host.Kernel = unix.ByteSliceToString(uname.Release[:])
+	}
+	host.CPUModel, _ = unix.Sysctl("machdep.cpu.brand_string")
+	host.Memory, _ = unix.SysctlUint64("hw.memsize")
+}
@@ -0,0 +1,64 @@
+package osutil
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/sha1n/bert/api"
+	"golang.org/x/sys/unix"
+)
+
+// loadShift the number of fractional bits of the load averages reported by sysinfo(2)
+const loadShift = 16
+
+// LoadAverage returns the 1, 5 and 15 minute load averages of the current host.
+func LoadAverage() ([]float64, error) {
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err != nil {
+		return nil, err
+	}
+
+	loadAverage := make([]float64, len(info.Loads))
+	for i, load := range info.Loads {
+		loadAverage[i] = float64(load) / (1 << loadShift)
+	}
+
+	return loadAverage, nil
+}
+
+func addPlatformHostInfo(host *api.HostInfo) {
+	var uname unix.Utsname
+	if err := unix.Uname(&uname); err == nil {
+		host.Kernel = unix.ByteSliceToString(uname.Release[:])
+	}
+
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err == nil {
+		host.Memory = uint64(info.Totalram) * uint64(info.Unit)
+	}
+
+	host.CPUModel = cpuModel()
+	if governor, err := os.ReadFile("/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor"); err == nil {
+		host.CPUGovernor = string(bytes.TrimSpace(governor))
+	}
+}
+
+// cpuModel returns the model name of the first CPU listed in '/proc/cpuinfo', or an empty string if it's not listed.
+func cpuModel() string {
+	cpuinfo, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	defer cpuinfo.Close()
+
+	scanner := bufio.NewScanner(cpuinfo)
+	for scanner.Scan() {
+		if name, value, ok := strings.Cut(scanner.Text(), ":"); ok && strings.TrimSpace(name) == "model name" {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
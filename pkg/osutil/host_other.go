@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+
+package osutil
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/sha1n/bert/api"
+)
+
+// LoadAverage load averages are not supported on this platform.
+func LoadAverage() ([]float64, error) {
+	return nil, fmt.Errorf("load averages are not supported on %s", runtime.GOOS)
+}
+
+// addPlatformHostInfo no additional host information is available on this platform.
+func addPlatformHostInfo(host *api.HostInfo) {}
@@ -0,0 +1,53 @@
+package reporthandlers
+
+import (
+	"errors"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+)
+
+type compositeReportHandler struct {
+	handlers    []api.ReportHandler
+	streams     []api.TraceStream
+	subscriber  exec.StreamSubscriber
+	unsubscribe exec.Unsubscribe
+}
+
+// NewCompositeReportHandler creates a report handler that broadcasts every trace event to all the specified handlers.
+func NewCompositeReportHandler(handlers ...api.ReportHandler) api.ReportHandler {
+	return &compositeReportHandler{
+		handlers: handlers,
+	}
+}
+
+func (h *compositeReportHandler) Subscribe(stream api.TraceStream) {
+	h.streams = make([]api.TraceStream, len(h.handlers))
+	for i, handler := range h.handlers {
+		h.streams[i] = make(api.TraceStream, cap(stream))
+		handler.Subscribe(h.streams[i])
+	}
+
+	h.subscriber = *exec.NewStreamSubscriber(stream, h.broadcast)
+	h.unsubscribe = h.subscriber.Subscribe()
+}
+
+// Finalize drains the source stream and finalizes all handlers. Returns the errors of all failing handlers.
+func (h *compositeReportHandler) Finalize() error {
+	h.unsubscribe()
+
+	var errs []error
+	for _, handler := range h.handlers {
+		errs = append(errs, handler.Finalize())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *compositeReportHandler) broadcast(trace api.Trace) error {
+	for _, stream := range h.streams {
+		stream <- trace
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package reporthandlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeReportHandlerBroadcastsToAllHandlers(t *testing.T) {
+	tracer := exec.NewTracer(2)
+	spec := exampleSpec()
+	interceptor1, interceptor2 := newWriteReportInterceptor(nil), newWriteReportInterceptor(nil)
+
+	handler := NewCompositeReportHandler(
+		NewSummaryReportHandler(spec, api.ReportContext{}, interceptor1.intercept),
+		NewSummaryReportHandler(spec, api.ReportContext{}, interceptor2.intercept),
+	)
+	handler.Subscribe(tracer.Stream())
+
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{}, nil)
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{}, nil)
+
+	assert.NoError(t, handler.Finalize())
+	assert.Equal(t, 2, interceptor1.capturedSummary.PerceivedTimeStats(spec.Scenarios[0].ID()).Count())
+	assert.Equal(t, 2, interceptor2.capturedSummary.PerceivedTimeStats(spec.Scenarios[0].ID()).Count())
+}
+
+func TestCompositeReportHandlerFinalizeError(t *testing.T) {
+	tracer := exec.NewTracer(1)
+	spec := exampleSpec()
+	expectedError := errors.New("test error")
+
+	handler := NewCompositeReportHandler(
+		NewSummaryReportHandler(spec, api.ReportContext{}, newWriteReportInterceptor(expectedError).intercept),
+		NewSummaryReportHandler(spec, api.ReportContext{}, newWriteReportInterceptor(nil).intercept),
+	)
+	handler.Subscribe(tracer.Stream())
+
+	assert.ErrorIs(t, handler.Finalize(), expectedError)
+}
@@ -0,0 +1,38 @@
+package reporthandlers
+
+import (
+	"io"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/results"
+)
+
+type resultsReportHandler struct {
+	spec        api.BenchmarkSpec
+	ctx         api.ReportContext
+	sink        exec.TraceSink
+	unsubscribe exec.Unsubscribe
+	writer      io.Writer
+}
+
+// NewResultsReportHandler creates a report handler that accumulates all trace events and saves them
+// along with the spec, labels and host information, so they can be loaded and compared later.
+func NewResultsReportHandler(spec api.BenchmarkSpec, ctx api.ReportContext, writer io.Writer) api.ReportHandler {
+	return &resultsReportHandler{
+		spec:   spec,
+		ctx:    ctx,
+		writer: writer,
+	}
+}
+
+func (h *resultsReportHandler) Subscribe(stream api.TraceStream) {
+	h.sink = *exec.NewTraceSink(stream)
+	h.unsubscribe = h.sink.Subscribe()
+}
+
+func (h *resultsReportHandler) Finalize() error {
+	h.unsubscribe()
+
+	return results.Save(results.NewResults(h.spec, h.ctx, h.sink.Traces()), h.writer)
+}
@@ -0,0 +1,33 @@
+package reporthandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsReportHandler(t *testing.T) {
+	tracer := exec.NewTracer(1)
+	spec := exampleSpec()
+	ctx := api.ReportContext{Labels: []string{"label"}}
+	buf := new(bytes.Buffer)
+
+	handler := NewResultsReportHandler(spec, ctx, buf)
+	handler.Subscribe(tracer.Stream())
+
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{}, nil)
+
+	assert.NoError(t, handler.Finalize())
+
+	var actual results.Results
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	assert.Equal(t, spec, actual.Spec)
+	assert.Equal(t, ctx.Labels, actual.Labels)
+	assert.Equal(t, 1, len(actual.Traces))
+	assert.Equal(t, spec.Scenarios[0].ID(), actual.Traces[0].ID)
+}
@@ -31,5 +31,10 @@ func (h *summaryReportHandler) Subscribe(stream api.TraceStream) {
 func (h *summaryReportHandler) Finalize() error {
 	h.unsubscribe()
 
-	return h.writeReportFn(h.sink.Summary(), h.spec, h.ctx)
+	return h.writeReportFn(h.sink.SummaryWith(summaryOptionsOf(h.ctx)), h.spec, h.ctx)
+}
+
+// summaryOptionsOf returns the summary options requested by the specified report context
+func summaryOptionsOf(ctx api.ReportContext) exec.SummaryOptions {
+	return exec.SummaryOptions{RemoveOutliers: ctx.RemoveOutliers}
 }
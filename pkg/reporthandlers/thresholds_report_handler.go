@@ -0,0 +1,34 @@
+package reporthandlers
+
+import (
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/thresholds"
+)
+
+type thresholdsReportHandler struct {
+	spec        api.BenchmarkSpec
+	ctx         api.ReportContext
+	sink        exec.TraceSink
+	unsubscribe exec.Unsubscribe
+}
+
+// NewThresholdsReportHandler creates a report handler that evaluates the thresholds defined in the specified spec.
+// Finalize returns a *thresholds.FailedError if any of the threshold checks fails.
+func NewThresholdsReportHandler(spec api.BenchmarkSpec, ctx api.ReportContext) api.ReportHandler {
+	return &thresholdsReportHandler{
+		spec: spec,
+		ctx:  ctx,
+	}
+}
+
+func (h *thresholdsReportHandler) Subscribe(stream api.TraceStream) {
+	h.sink = *exec.NewTraceSink(stream)
+	h.unsubscribe = h.sink.Subscribe()
+}
+
+func (h *thresholdsReportHandler) Finalize() error {
+	h.unsubscribe()
+
+	return thresholds.Evaluate(h.spec, h.sink.SummaryWith(summaryOptionsOf(h.ctx)), h.ctx.BaselineSummary).Err()
+}
@@ -0,0 +1,43 @@
+package reporthandlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/sha1n/bert/pkg/thresholds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdsReportHandlerWithPassingThresholds(t *testing.T) {
+	spec := aSpecWithMeanThreshold(time.Second)
+
+	assert.NoError(t, runThresholdsReportHandler(spec, time.Millisecond))
+}
+
+func TestThresholdsReportHandlerWithFailingThresholds(t *testing.T) {
+	spec := aSpecWithMeanThreshold(time.Millisecond)
+
+	err := runThresholdsReportHandler(spec, time.Second)
+
+	assert.Error(t, err)
+	assert.IsType(t, &thresholds.FailedError{}, err)
+}
+
+func runThresholdsReportHandler(spec api.BenchmarkSpec, perceivedTime time.Duration) error {
+	tracer := exec.NewTracer(1)
+	handler := NewThresholdsReportHandler(spec, api.ReportContext{})
+	handler.Subscribe(tracer.Stream())
+
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{PerceivedTime: perceivedTime}, nil)
+
+	return handler.Finalize()
+}
+
+func aSpecWithMeanThreshold(mean time.Duration) api.BenchmarkSpec {
+	spec := exampleSpec()
+	spec.Scenarios[0].Thresholds = &api.ThresholdsSpec{Mean: api.Duration(mean)}
+
+	return spec
+}
@@ -0,0 +1,175 @@
+package results
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/osutil"
+)
+
+// Results a persistable document that contains the raw trace data of a benchmark run, along with
+// the information required to interpret it at a later time.
+type Results struct {
+	Timestamp time.Time           `json:"timestamp"`
+	Labels    []string            `json:"labels,omitempty"`
+	Metadata  api.RunMetadata     `json:"metadata"`
+	Spec      api.BenchmarkSpec   `json:"spec"`
+	Filter    *api.ScenarioFilter `json:"filter,omitempty"`
+	Traces    []TraceRecord       `json:"traces"`
+}
+
+// TraceRecord a persistable representation of an api.Trace
+type TraceRecord struct {
+	ID            api.ID            `json:"id"`
+	PerceivedTime time.Duration     `json:"perceivedTime"`
+	UserCPUTime   time.Duration     `json:"userCPUTime"`
+	SystemCPUTime time.Duration     `json:"systemCPUTime"`
+	ResourceUsage api.ResourceUsage `json:"resourceUsage"`
+	Concurrency   int               `json:"concurrency,omitempty"`
+	Wait          time.Duration     `json:"wait,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	Timeout       bool              `json:"timeout,omitempty"`
+}
+
+// NewResults creates a new Results document for the specified spec, report context and traces.
+// When the report context has no run metadata, information about the current host is recorded instead.
+func NewResults(spec api.BenchmarkSpec, ctx api.ReportContext, tracesByID map[api.ID][]api.Trace) Results {
+	results := Results{
+		Timestamp: time.Now(),
+		Labels:    ctx.Labels,
+		Spec:      spec,
+		Traces:    []TraceRecord{},
+	}
+	if ctx.Metadata != nil {
+		results.Metadata = *ctx.Metadata
+	} else {
+		results.Metadata.Host = osutil.CurrentHost()
+	}
+	if !ctx.Filter.IsEmpty() {
+		results.Filter = &ctx.Filter
+	}
+
+	// keep the original scenario order, so that results are easy to read
+	for _, scenario := range spec.Scenarios {
+		for _, trace := range tracesByID[scenario.ID()] {
+			results.Traces = append(results.Traces, newTraceRecord(trace))
+		}
+	}
+
+	return results
+}
+
+// TracesByID returns the traces of this document grouped by ID, in the form expected by exec.NewSummary.
+func (r Results) TracesByID() map[api.ID][]api.Trace {
+	tracesByID := make(map[api.ID][]api.Trace)
+	for _, record := range r.Traces {
+		tracesByID[record.ID] = append(tracesByID[record.ID], persistedTrace{record: record})
+	}
+
+	return tracesByID
+}
+
+// Save writes the specified results to the specified writer in JSON format.
+func Save(results Results, writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+// Load loads results from the specified file.
+func Load(path string) (results Results, err error) {
+	var bytes []byte
+
+	slog.Info(fmt.Sprintf("Loading benchmark results from '%s'...", path))
+
+	if bytes, err = os.ReadFile(path); err == nil {
+		err = json.Unmarshal(bytes, &results)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to load results from '%s'. %w", path, err)
+	}
+
+	return results, err
+}
+
+func newTraceRecord(trace api.Trace) TraceRecord {
+	record := TraceRecord{
+		ID:            trace.ID(),
+		PerceivedTime: trace.PerceivedTime(),
+		UserCPUTime:   trace.UserCPUTime(),
+		SystemCPUTime: trace.SystemCPUTime(),
+		ResourceUsage: trace.ResourceUsage(),
+		Concurrency:   trace.Concurrency(),
+		Wait:          trace.Wait(),
+	}
+	if trace.Error() != nil {
+		record.Error = trace.Error().Error()
+		record.Timeout = errors.Is(trace.Error(), api.ErrTimeout)
+	}
+
+	return record
+}
+
+// persistedTrace an api.Trace implementation backed by a TraceRecord
+type persistedTrace struct {
+	record TraceRecord
+}
+
+func (t persistedTrace) ID() string {
+	return t.record.ID
+}
+
+func (t persistedTrace) PerceivedTime() time.Duration {
+	return t.record.PerceivedTime
+}
+
+func (t persistedTrace) SystemCPUTime() time.Duration {
+	return t.record.SystemCPUTime
+}
+
+func (t persistedTrace) UserCPUTime() time.Duration {
+	return t.record.UserCPUTime
+}
+
+func (t persistedTrace) ResourceUsage() api.ResourceUsage {
+	return t.record.ResourceUsage
+}
+
+func (t persistedTrace) Concurrency() int {
+	return t.record.Concurrency
+}
+
+func (t persistedTrace) Wait() time.Duration {
+	return t.record.Wait
+}
+
+func (t persistedTrace) Error() error {
+	if t.record.Error == "" {
+		return nil
+	}
+
+	return persistedError{message: t.record.Error, timeout: t.record.Timeout}
+}
+
+// persistedError an error restored from a TraceRecord
+type persistedError struct {
+	message string
+	timeout bool
+}
+
+func (e persistedError) Error() string {
+	return e.message
+}
+
+// Is reports whether this error is api.ErrTimeout, if it has been recorded as a timeout
+func (e persistedError) Is(target error) bool {
+	return e.timeout && target == api.ErrTimeout
+}
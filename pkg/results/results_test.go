@@ -0,0 +1,143 @@
+package results
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	spec := aSpec()
+	ctx := api.ReportContext{Labels: []string{"label"}, Filter: api.ScenarioFilter{Only: []string{"a", "b"}}}
+	traces := aTracesByID(spec)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, Save(NewResults(spec, ctx, traces), buf))
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	loaded, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, spec, loaded.Spec)
+	assert.Equal(t, ctx.Labels, loaded.Labels)
+	assert.Equal(t, &ctx.Filter, loaded.Filter)
+	assert.NotEmpty(t, loaded.Metadata.Host.OS, "the current host is expected to be recorded when there is no run metadata")
+	assert.Equal(t, 3, len(loaded.Traces))
+
+	loadedTraces := loaded.TracesByID()
+	assert.Equal(t, 2, len(loadedTraces["a"]))
+	assert.Equal(t, 1, len(loadedTraces["b"]))
+	assert.Equal(t, time.Second, loadedTraces["a"][0].PerceivedTime())
+	assert.Equal(t, time.Millisecond, loadedTraces["a"][0].UserCPUTime())
+	assert.Equal(t, time.Microsecond, loadedTraces["a"][0].SystemCPUTime())
+	assert.Equal(t, api.ResourceUsage{MaxRSS: 1024, MinorPageFaults: 10}, loadedTraces["a"][0].ResourceUsage())
+	assert.Equal(t, 2*time.Second, loadedTraces["a"][0].Wait())
+	assert.NoError(t, loadedTraces["a"][0].Error())
+	assert.EqualError(t, loadedTraces["b"][0].Error(), "failed")
+
+	summary := exec.NewSummary(loadedTraces)
+	assert.Equal(t, 1.0, summary.PerceivedTimeStats("b").ErrorRate())
+}
+
+func TestSaveAndLoadTimeouts(t *testing.T) {
+	spec := aSpec()
+	tracer := exec.NewTracer(10)
+	sink := exec.NewTraceSink(tracer.Stream())
+	unsubscribe := sink.Subscribe()
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{PerceivedTime: time.Second}, fmt.Errorf("command %w", api.ErrTimeout))
+	tracer.Start(spec.Scenarios[0])(&api.ExecutionInfo{PerceivedTime: time.Second}, errors.New("failed"))
+	unsubscribe()
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, Save(NewResults(spec, api.ReportContext{}, sink.Traces()), buf))
+	path := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	loaded, err := Load(path)
+
+	assert.NoError(t, err)
+	loadedTraces := loaded.TracesByID()["a"]
+	assert.ErrorIs(t, loadedTraces[0].Error(), api.ErrTimeout)
+	assert.EqualError(t, loadedTraces[0].Error(), "command timed out")
+	assert.Error(t, loadedTraces[1].Error())
+	assert.NotErrorIs(t, loadedTraces[1].Error(), api.ErrTimeout)
+}
+
+func TestSaveAndLoadRunMetadata(t *testing.T) {
+	spec := aSpec()
+	startTime := time.Now().UTC().Truncate(time.Second)
+	metadata := &api.RunMetadata{
+		StartTime: startTime,
+		Host:      api.HostInfo{Hostname: "host", Kernel: "6.1.0", LoadAverage: []float64{0.5, 0.25, 0.1}},
+		Version:   "v1.0.0",
+		Flags:     map[string][]string{"executions": {"10"}},
+	}
+	metadata.End(startTime.Add(time.Minute))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, Save(NewResults(spec, api.ReportContext{Metadata: metadata}, aTracesByID(spec)), buf))
+	path := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	loaded, err := Load(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, *metadata, loaded.Metadata)
+	assert.Equal(t, api.Duration(time.Minute), loaded.Metadata.Duration)
+}
+
+func TestLoadNonExistingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Error(t, err)
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+
+	_, err := Load(path)
+
+	assert.Error(t, err)
+}
+
+func aSpec() api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Executions: 2,
+		Scenarios: []api.ScenarioSpec{
+			{Name: "a", Command: &api.CommandSpec{Cmd: []string{"cmd", "a"}}},
+			{Name: "b", Command: &api.CommandSpec{Cmd: []string{"cmd", "b"}}},
+		},
+	}
+}
+
+func aTracesByID(spec api.BenchmarkSpec) map[api.ID][]api.Trace {
+	tracer := exec.NewTracer(10)
+	sink := exec.NewTraceSink(tracer.Stream())
+	unsubscribe := sink.Subscribe()
+
+	info := &api.ExecutionInfo{
+		PerceivedTime: time.Second,
+		UserTime:      time.Millisecond,
+		SystemTime:    time.Microsecond,
+		ResourceUsage: api.ResourceUsage{MaxRSS: 1024, MinorPageFaults: 10},
+		Wait:          2 * time.Second,
+	}
+	tracer.Start(spec.Scenarios[0])(info, nil)
+	tracer.Start(spec.Scenarios[0])(info, nil)
+	tracer.Start(spec.Scenarios[1])(info, errors.New("failed"))
+
+	unsubscribe()
+
+	return sink.Traces()
+}
@@ -0,0 +1,104 @@
+package specs
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sha1n/bert/api"
+)
+
+// FilterScenarios returns a copy of the specified spec that only contains the scenarios the specified filter selects.
+// Returns an error if a pattern is invalid, or if no scenario is selected.
+func FilterScenarios(spec api.BenchmarkSpec, filter api.ScenarioFilter) (api.BenchmarkSpec, error) {
+	if filter.IsEmpty() {
+		return spec, nil
+	}
+
+	only, err := compilePatterns(filter.Only)
+	if err != nil {
+		return spec, err
+	}
+	skip, err := compilePatterns(filter.Skip)
+	if err != nil {
+		return spec, err
+	}
+
+	selected := []api.ScenarioSpec{}
+	for _, scenario := range spec.Scenarios {
+		if (len(only) == 0 || only.match(scenario.Name)) && hasAnyTag(scenario, filter.Tags) && !skip.match(scenario.Name) {
+			selected = append(selected, scenario)
+		}
+	}
+
+	warnUnmatched(spec.Scenarios, only, skip, filter.Tags)
+	if len(selected) == 0 {
+		return spec, fmt.Errorf("no scenarios match the filter '%s'", filter)
+	}
+
+	spec.Scenarios = selected
+
+	return spec, nil
+}
+
+// namePattern matches scenario names exactly, using a glob or using a regular expression
+type namePattern struct {
+	raw    string
+	regexp *regexp.Regexp
+}
+
+type namePatterns []namePattern
+
+func compilePatterns(patterns []string) (compiled namePatterns, err error) {
+	for _, raw := range patterns {
+		pattern := namePattern{raw: raw}
+		if len(raw) > 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") {
+			if pattern.regexp, err = regexp.Compile(raw[1 : len(raw)-1]); err != nil {
+				return nil, fmt.Errorf("invalid scenario pattern '%s'. %w", raw, err)
+			}
+		} else if _, err = path.Match(raw, ""); err != nil {
+			return nil, fmt.Errorf("invalid scenario pattern '%s'. %w", raw, err)
+		}
+
+		compiled = append(compiled, pattern)
+	}
+
+	return compiled, nil
+}
+
+func (p namePattern) match(name string) bool {
+	if p.regexp != nil {
+		return p.regexp.MatchString(name)
+	}
+	if p.raw == name {
+		return true
+	}
+	matched, _ := path.Match(p.raw, name)
+
+	return matched
+}
+
+func (ps namePatterns) match(name string) bool {
+	return slices.ContainsFunc(ps, func(p namePattern) bool { return p.match(name) })
+}
+
+func hasAnyTag(scenario api.ScenarioSpec, tags []string) bool {
+	return len(tags) == 0 || slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(scenario.Tags, tag) })
+}
+
+// warnUnmatched logs a warning for every pattern and tag that doesn't match any scenario, since they are most likely typos.
+func warnUnmatched(scenarios []api.ScenarioSpec, only namePatterns, skip namePatterns, tags []string) {
+	for _, p := range slices.Concat(only, skip) {
+		if !slices.ContainsFunc(scenarios, func(s api.ScenarioSpec) bool { return p.match(s.Name) }) {
+			slog.Warn(fmt.Sprintf("The scenario pattern '%s' doesn't match any scenario", p.raw))
+		}
+	}
+	for _, tag := range tags {
+		if !slices.ContainsFunc(scenarios, func(s api.ScenarioSpec) bool { return slices.Contains(s.Tags, tag) }) {
+			slog.Warn(fmt.Sprintf("The tag '%s' doesn't match any scenario", tag))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package specs
+
+import (
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterScenarios(t *testing.T) {
+	examples := map[string]struct {
+		filter   api.ScenarioFilter
+		expected []string
+	}{
+		"empty filter":        {api.ScenarioFilter{}, []string{"build debug", "build release", "[go test]", "lint"}},
+		"exact names":         {api.ScenarioFilter{Only: []string{"lint", "[go test]"}}, []string{"[go test]", "lint"}},
+		"glob":                {api.ScenarioFilter{Only: []string{"build *"}}, []string{"build debug", "build release"}},
+		"regexp":              {api.ScenarioFilter{Only: []string{"/de?bu[g]$/"}}, []string{"build debug"}},
+		"tags":                {api.ScenarioFilter{Tags: []string{"fast", "unknown"}}, []string{"build debug", "lint"}},
+		"skip":                {api.ScenarioFilter{Skip: []string{"build*"}}, []string{"[go test]", "lint"}},
+		"only, tags and skip": {api.ScenarioFilter{Only: []string{"build*", "lint"}, Tags: []string{"fast"}, Skip: []string{"lint"}}, []string{"build debug"}},
+	}
+
+	for description, example := range examples {
+		filtered, err := FilterScenarios(aSpecToFilter(), example.filter)
+
+		assert.NoError(t, err, description)
+		assert.Equal(t, example.expected, scenarioNamesOf(filtered), description)
+	}
+}
+
+func TestFilterScenariosWithoutMatches(t *testing.T) {
+	_, err := FilterScenarios(aSpecToFilter(), api.ScenarioFilter{Only: []string{"test"}, Tags: []string{"slow"}})
+
+	assert.EqualError(t, err, "no scenarios match the filter 'only=test tags=slow'")
+}
+
+func TestFilterScenariosWithInvalidPatterns(t *testing.T) {
+	for _, filter := range []api.ScenarioFilter{{Only: []string{"/(unclosed/"}}, {Skip: []string{"[unclosed"}}} {
+		_, err := FilterScenarios(aSpecToFilter(), filter)
+
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestFilterScenariosDoesNotModifyTheOriginalSpec(t *testing.T) {
+	spec := aSpecToFilter()
+
+	_, _ = FilterScenarios(spec, api.ScenarioFilter{Only: []string{"lint"}})
+
+	assert.Equal(t, aSpecToFilter(), spec)
+}
+
+func aSpecToFilter() api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Executions: 1,
+		Scenarios: []api.ScenarioSpec{
+			{Name: "build debug", Tags: []string{"build", "fast"}},
+			{Name: "build release", Tags: []string{"build"}},
+			{Name: "[go test]"},
+			{Name: "lint", Tags: []string{"fast"}},
+		},
+	}
+}
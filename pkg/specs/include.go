@@ -0,0 +1,122 @@
+package specs
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/osutil"
+)
+
+// includedFileProperties the top-level properties included files may declare
+var includedFileProperties = []string{"include", "scenarios"}
+
+// includedFile the parts of an included file that are loaded. Included files that declare other properties are rejected.
+type includedFile struct {
+	Include   []string           `json:"include,omitempty" yaml:"include,omitempty"`
+	Scenarios []api.ScenarioSpec `json:"scenarios" yaml:"scenarios"`
+}
+
+// includeScenarios appends the scenarios of the files included by the specified spec, and of the files they include,
+// to the scenarios of the spec. The scenarios of each file are followed by the scenarios of the files it includes, in order.
+// Returns the scenarios along with the files they were loaded from.
+func includeScenarios(spec api.BenchmarkSpec, path string) ([]api.ScenarioSpec, scenarioSources, error) {
+	scenarios := spec.Scenarios
+	sources := make(scenarioSources, len(scenarios))
+	for i := range sources {
+		sources[i] = path
+	}
+
+	visiting := map[string]bool{}
+	if path != "" {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		visiting[absPath] = true
+	}
+
+	included, includedSources, err := loadIncludes(spec.Include, path, visiting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(scenarios, included...), append(sources, includedSources...), nil
+}
+
+func loadIncludes(includes []string, from string, visiting map[string]bool) (scenarios []api.ScenarioSpec, sources scenarioSources, err error) {
+	for _, include := range includes {
+		var path string
+		if path, err = resolveIncludePath(include, from); err != nil {
+			return nil, nil, err
+		}
+		if visiting[path] {
+			return nil, nil, fmt.Errorf("'%s' includes '%s', which is already being included", describeFile(from), include)
+		}
+
+		var file includedFile
+		if err = unmarshalIncludedFile(path, &file); err != nil {
+			return nil, nil, fmt.Errorf("failed to include '%s' in '%s'. %w", include, describeFile(from), err)
+		}
+
+		scenarios = append(scenarios, file.Scenarios...)
+		for range file.Scenarios {
+			sources = append(sources, path)
+		}
+
+		visiting[path] = true
+		nested, nestedSources, err := loadIncludes(file.Include, path, visiting)
+		delete(visiting, path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		scenarios = append(scenarios, nested...)
+		sources = append(sources, nestedSources...)
+	}
+
+	return scenarios, sources, nil
+}
+
+// unmarshalIncludedFile loads the specified included file. Returns an error if the file declares top-level properties
+// that aren't loaded from included files, so settings such as 'defaults' aren't silently dropped.
+func unmarshalIncludedFile(path string, file *includedFile) error {
+	var properties map[string]any
+	if err := unmarshalFile(path, &properties); err != nil {
+		return err
+	}
+
+	var unsupported []string
+	for name := range properties {
+		if !slices.Contains(includedFileProperties, name) {
+			unsupported = append(unsupported, name)
+		}
+	}
+	if len(unsupported) > 0 {
+		slices.Sort(unsupported)
+		return fmt.Errorf("unsupported properties '%s'. included files may only declare '%s'",
+			strings.Join(unsupported, "', '"), strings.Join(includedFileProperties, "' and '"))
+	}
+
+	return unmarshalFile(path, file)
+}
+
+// resolveIncludePath returns the absolute path of an included file. Relative paths are relative to the directory of the including file.
+func resolveIncludePath(include string, from string) (string, error) {
+	path := osutil.ExpandUserPath(include)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+
+	return filepath.Abs(path)
+}
+
+func describeFile(path string) string {
+	if path == "" {
+		return "<inline spec>"
+	}
+
+	return path
+}
@@ -0,0 +1,156 @@
+package specs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSpecWithIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- scenarios/builds.yaml
+- tests.json
+defaults:
+  workingDir: /default
+scenarios:
+- name: local
+  command:
+    cmd:
+    - local
+`)
+	writeSpecFile(t, dir, "scenarios/builds.yaml", `include:
+- base.yaml
+scenarios:
+- name: build
+  extends: base
+`)
+	writeSpecFile(t, dir, "scenarios/base.yaml", `scenarios:
+- name: base
+  template: true
+  command:
+    cmd:
+    - make
+`)
+	writeSpecFile(t, dir, "tests.json", `{"scenarios": [{"name": "test", "command": {"cmd": ["make", "test"]}}]}`)
+
+	spec, err := LoadSpec(filepath.Join(dir, "bench.yaml"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Include)
+	assert.Equal(t, []string{"local", "build", "test"}, scenarioNamesOf(spec))
+	assert.Equal(t, []string{"make"}, spec.Scenarios[1].Command.Cmd)
+	for _, scenario := range spec.Scenarios {
+		assert.Equal(t, "/default", scenario.WorkingDirectory, "defaults are expected to apply to included scenarios")
+	}
+}
+
+func TestLoadSpecWithMissingInclude(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- missing.yaml
+scenarios: []
+`)
+
+	_, err := LoadSpec(path)
+
+	assert.ErrorContains(t, err, "failed to include 'missing.yaml' in '"+path+"'")
+}
+
+func TestLoadSpecWithCyclicIncludes(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- a.yaml
+`)
+	writeSpecFile(t, dir, "a.yaml", `include:
+- b.yaml
+`)
+	writeSpecFile(t, dir, "b.yaml", `include:
+- bench.yaml
+`)
+
+	_, err := LoadSpec(path)
+
+	assert.EqualError(t, err, "'"+filepath.Join(dir, "b.yaml")+"' includes 'bench.yaml', which is already being included")
+}
+
+func TestLoadSpecWithUnsupportedIncludedProperties(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- included.yaml
+scenarios: []
+`)
+	writeSpecFile(t, dir, "included.yaml", `vars:
+  name: value
+defaults:
+  workingDir: /default
+scenarios:
+- name: included
+  command:
+    cmd:
+    - test
+`)
+
+	_, err := LoadSpec(path)
+
+	assert.EqualError(t, err, "failed to include 'included.yaml' in '"+path+"'. unsupported properties 'defaults', 'vars'. included files may only declare 'include' and 'scenarios'")
+}
+
+func TestLoadSpecWithInvalidIncludedScenario(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- included.yaml
+scenarios:
+- name: valid
+  command:
+    cmd:
+    - test
+`)
+	included := writeSpecFile(t, dir, "included.yaml", `scenarios:
+- name: invalid
+  command:
+    workingDir: /dir
+`)
+
+	_, err := LoadSpec(path)
+
+	assert.ErrorContains(t, err, "scenario 'invalid' in '"+included+"': Cmd is a required field")
+}
+
+func TestLoadSpecWithDuplicateIncludedScenario(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSpecFile(t, dir, "bench.yaml", `executions: 10
+include:
+- included.yaml
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`)
+	included := writeSpecFile(t, dir, "included.yaml", `scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`)
+
+	_, err := LoadSpec(path)
+
+	assert.ErrorContains(t, err, "scenario 'test' in '"+included+"': the name is already used by scenario 'test' in '"+path+"'")
+}
+
+func writeSpecFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
@@ -0,0 +1,138 @@
+package specs
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+
+	"github.com/sha1n/bert/api"
+)
+
+// scenarioSources the files the scenarios of a spec were loaded from, by scenario index.
+// Scenarios that were not loaded from a file have an empty source.
+type scenarioSources []string
+
+// describe returns a description of the specified scenario, including the file it was loaded from.
+func (s scenarioSources) describe(scenarios []api.ScenarioSpec, i int) string {
+	if i >= len(s) || s[i] == "" {
+		return fmt.Sprintf("scenario '%s'", scenarios[i].Name)
+	}
+
+	return fmt.Sprintf("scenario '%s' in '%s'", scenarios[i].Name, s[i])
+}
+
+// resolveExtends applies the values of extended scenarios to the scenarios that extend them and removes templates.
+// Scenarios inherit every value they don't set from the scenario they extend, which may extend another scenario in turn.
+// Environment variables are merged, with the values of the extending scenario taking precedence.
+func resolveExtends(scenarios []api.ScenarioSpec, sources scenarioSources) ([]api.ScenarioSpec, scenarioSources, error) {
+	indexByName := map[string]int{}
+	for i := range scenarios {
+		if _, exists := indexByName[scenarios[i].Name]; !exists {
+			indexByName[scenarios[i].Name] = i
+		}
+	}
+
+	resolved := make([]*api.ScenarioSpec, len(scenarios))
+	visiting := make([]bool, len(scenarios))
+
+	var resolve func(i int) (api.ScenarioSpec, error)
+	resolve = func(i int) (api.ScenarioSpec, error) {
+		if resolved[i] != nil {
+			return *resolved[i], nil
+		}
+		if visiting[i] {
+			return api.ScenarioSpec{}, fmt.Errorf("%s extends itself through '%s'", sources.describe(scenarios, i), scenarios[i].Extends)
+		}
+
+		scenario := scenarios[i]
+		if scenario.Extends != "" {
+			parentIndex, ok := indexByName[scenario.Extends]
+			if !ok {
+				return scenario, fmt.Errorf("%s extends an undefined scenario '%s'", sources.describe(scenarios, i), scenario.Extends)
+			}
+
+			visiting[i] = true
+			parent, err := resolve(parentIndex)
+			visiting[i] = false
+			if err != nil {
+				return scenario, err
+			}
+			scenario = inherit(scenario, parent)
+		}
+		resolved[i] = &scenario
+
+		return scenario, nil
+	}
+
+	result := []api.ScenarioSpec{}
+	resultSources := scenarioSources{}
+	for i := range scenarios {
+		scenario, err := resolve(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		if scenario.Template {
+			continue
+		}
+
+		result = append(result, scenario)
+		resultSources = append(resultSources, sources[i])
+	}
+
+	return result, resultSources, nil
+}
+
+func inherit(scenario api.ScenarioSpec, parent api.ScenarioSpec) api.ScenarioSpec {
+	inherited := scenario
+	inherited.WorkingDirectory = cmp.Or(scenario.WorkingDirectory, parent.WorkingDirectory)
+	inherited.Env = mergeEnv(parent.Env, scenario.Env)
+	inherited.Warmup = cmp.Or(scenario.Warmup, parent.Warmup)
+	inherited.BeforeAll = cmp.Or(scenario.BeforeAll, parent.BeforeAll)
+	inherited.AfterAll = cmp.Or(scenario.AfterAll, parent.AfterAll)
+	inherited.BeforeEach = cmp.Or(scenario.BeforeEach, parent.BeforeEach)
+	inherited.AfterEach = cmp.Or(scenario.AfterEach, parent.AfterEach)
+	inherited.Command = cmp.Or(scenario.Command, parent.Command)
+	inherited.Thresholds = cmp.Or(scenario.Thresholds, parent.Thresholds)
+	inherited.MaxErrors = cmp.Or(scenario.MaxErrors, parent.MaxErrors)
+	inherited.Isolation = cmp.Or(scenario.Isolation, parent.Isolation)
+	inherited.Executions = cmp.Or(scenario.Executions, parent.Executions)
+	inherited.MaxDuration = cmp.Or(scenario.MaxDuration, parent.MaxDuration)
+	if scenario.Parameters == nil {
+		inherited.Parameters = parent.Parameters
+	}
+	if scenario.Tags == nil {
+		inherited.Tags = parent.Tags
+	}
+
+	return inherited
+}
+
+// applyDefaults sets the default values of a benchmark on all of its scenarios that don't set their own.
+func applyDefaults(scenarios []api.ScenarioSpec, defaults *api.DefaultsSpec) {
+	if defaults == nil {
+		return
+	}
+
+	for i := range scenarios {
+		scenario := &scenarios[i]
+		scenario.WorkingDirectory = cmp.Or(scenario.WorkingDirectory, defaults.WorkingDirectory)
+		scenario.Env = mergeEnv(defaults.Env, scenario.Env)
+		scenario.BeforeAll = cmp.Or(scenario.BeforeAll, defaults.BeforeAll)
+		scenario.AfterAll = cmp.Or(scenario.AfterAll, defaults.AfterAll)
+		scenario.BeforeEach = cmp.Or(scenario.BeforeEach, defaults.BeforeEach)
+		scenario.AfterEach = cmp.Or(scenario.AfterEach, defaults.AfterEach)
+		scenario.Isolation = cmp.Or(scenario.Isolation, defaults.Isolation)
+	}
+}
+
+// mergeEnv returns the union of the specified environment variables, with overrides taking precedence.
+func mergeEnv(env map[string]string, overrides map[string]string) map[string]string {
+	if len(env) == 0 {
+		return overrides
+	}
+
+	merged := maps.Clone(env)
+	maps.Copy(merged, overrides)
+
+	return merged
+}
@@ -0,0 +1,215 @@
+package specs
+
+import (
+	"testing"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSpecWithDefaults(t *testing.T) {
+	example := `executions: 10
+defaults:
+  workingDir: /default
+  env:
+    A: default-a
+    B: default-b
+  beforeAll:
+    cmd:
+    - setup
+  isolation:
+    cpus: [1]
+scenarios:
+- name: inherits
+  env:
+    B: b
+  command:
+    cmd:
+    - test
+- name: overrides
+  workingDir: /dir
+  beforeAll:
+    cmd:
+    - custom-setup
+  command:
+    cmd:
+    - test
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Nil(t, spec.Defaults)
+
+	inherits, overrides := spec.Scenarios[0], spec.Scenarios[1]
+	assert.Equal(t, "/default", inherits.WorkingDirectory)
+	assert.Equal(t, map[string]string{"A": "default-a", "B": "b"}, inherits.Env)
+	assert.Equal(t, []string{"setup"}, inherits.BeforeAll.Cmd)
+	assert.Nil(t, inherits.AfterAll)
+	assert.Equal(t, []int{1}, inherits.Isolation.CPUs)
+
+	assert.Equal(t, "/dir", overrides.WorkingDirectory)
+	assert.Equal(t, map[string]string{"A": "default-a", "B": "default-b"}, overrides.Env)
+	assert.Equal(t, []string{"custom-setup"}, overrides.BeforeAll.Cmd)
+}
+
+func TestLoadSpecWithZeroWarmupOverride(t *testing.T) {
+	example := `executions: 10
+warmup: 3
+scenarios:
+- name: base
+  template: true
+  warmup: 2
+  command:
+    cmd:
+    - build
+- name: inherits
+  command:
+    cmd:
+    - test
+- name: overrides-benchmark
+  warmup: 0
+  command:
+    cmd:
+    - test
+- name: overrides-template
+  extends: base
+  warmup: 0
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, spec.WarmupExecutions(spec.Scenarios[0]))
+	assert.Equal(t, 0, spec.WarmupExecutions(spec.Scenarios[1]), "an explicit zero warmup is expected to override the benchmark level value")
+	assert.Equal(t, 0, spec.WarmupExecutions(spec.Scenarios[2]), "an explicit zero warmup is expected to override the template")
+}
+
+func TestLoadSpecWithExtends(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: base
+  template: true
+  tags: [build]
+  workingDir: /base
+  warmup: 2
+  env:
+    MODE: base
+    LEVEL: "1"
+  command:
+    cmd:
+    - build
+- name: child
+  extends: base
+  executions: 3
+  env:
+    MODE: child
+- name: grandchild
+  extends: child
+  warmup: 5
+  command:
+    cmd:
+    - build
+    - --fast
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"child", "grandchild"}, scenarioNamesOf(spec), "templates are not expected to be executed")
+
+	child, grandchild := spec.Scenarios[0], spec.Scenarios[1]
+	assert.Equal(t, "/base", child.WorkingDirectory)
+	assert.Equal(t, 2, *child.Warmup)
+	assert.Equal(t, map[string]string{"MODE": "child", "LEVEL": "1"}, child.Env)
+	assert.Equal(t, []string{"build"}, child.Command.Cmd)
+	assert.Equal(t, []string{"build"}, child.Tags)
+	assert.Equal(t, 3, child.Executions)
+
+	assert.Equal(t, "/base", grandchild.WorkingDirectory)
+	assert.Equal(t, 5, *grandchild.Warmup)
+	assert.Equal(t, 3, grandchild.Executions)
+	assert.Equal(t, map[string]string{"MODE": "child", "LEVEL": "1"}, grandchild.Env)
+	assert.Equal(t, []string{"build", "--fast"}, grandchild.Command.Cmd)
+}
+
+func TestLoadSpecWithExtendedParameters(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: base
+  template: true
+  parameters:
+    threads: [1, 2]
+  command:
+    cmd:
+    - build
+    - -j${threads}
+- name: build ${threads}
+  extends: base
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build 1", "build 2"}, scenarioNamesOf(spec))
+}
+
+func TestLoadSpecWithInvalidExtends(t *testing.T) {
+	examples := map[string]string{
+		"scenario 'a' extends an undefined scenario 'b'": `
+- name: a
+  extends: b
+  command:
+    cmd:
+    - test
+`,
+		"scenario 'a' extends itself through 'b'": `
+- name: a
+  extends: b
+- name: b
+  extends: a
+`,
+	}
+
+	for expected, scenarios := range examples {
+		_, err := LoadSpecFromYamlData([]byte("executions: 10\nscenarios:" + scenarios))
+
+		assert.EqualError(t, err, expected)
+	}
+}
+
+func TestLoadSpecWithDuplicateScenarioNames(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: a
+  command:
+    cmd:
+    - test
+- name: a
+  command:
+    cmd:
+    - test
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.ErrorContains(t, err, "scenario 'a': the name is already used by scenario 'a'")
+}
+
+func TestMergeEnv(t *testing.T) {
+	assert.Nil(t, mergeEnv(nil, nil))
+	assert.Equal(t, map[string]string{"A": "a"}, mergeEnv(nil, map[string]string{"A": "a"}))
+	assert.Equal(t, map[string]string{"A": "a"}, mergeEnv(map[string]string{"A": "a"}, nil))
+
+	env := map[string]string{"A": "a", "B": "b"}
+	assert.Equal(t, map[string]string{"A": "override", "B": "b"}, mergeEnv(env, map[string]string{"A": "override"}))
+	assert.Equal(t, map[string]string{"A": "a", "B": "b"}, env, "the original environment is not expected to be modified")
+}
+
+func TestApplyDefaultsWithoutDefaults(t *testing.T) {
+	scenarios := []api.ScenarioSpec{{Name: "a"}}
+
+	applyDefaults(scenarios, nil)
+
+	assert.Equal(t, []api.ScenarioSpec{{Name: "a"}}, scenarios)
+}
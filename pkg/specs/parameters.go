@@ -0,0 +1,165 @@
+package specs
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sha1n/bert/api"
+)
+
+// expandParameters replaces every scenario that has parameters with one scenario per combination of parameter values.
+// '${name}' references to parameters are substituted in the name, working directories, environment variables and command lines
+// of each expanded scenario. Scenarios whose name doesn't reference any parameter are named after their parameter values.
+func expandParameters(spec api.BenchmarkSpec) (api.BenchmarkSpec, error) {
+	scenarios := []api.ScenarioSpec{}
+	names := map[string]bool{}
+
+	for _, scenario := range spec.Scenarios {
+		expanded, err := expandScenario(scenario)
+		if err != nil {
+			return spec, err
+		}
+
+		for _, s := range expanded {
+			if len(scenario.Parameters) > 0 && names[s.Name] {
+				return spec, fmt.Errorf("scenario '%s' expands to '%s', which is already defined", scenario.Name, s.Name)
+			}
+			names[s.Name] = true
+		}
+		scenarios = append(scenarios, expanded...)
+	}
+
+	spec.Scenarios = scenarios
+
+	return spec, nil
+}
+
+// expansionsOf returns the number of scenarios the specified scenario is expanded into.
+func expansionsOf(scenario api.ScenarioSpec) int {
+	expansions := 1
+	for _, values := range scenario.Parameters {
+		expansions *= len(values)
+	}
+
+	return expansions
+}
+
+func expandScenario(scenario api.ScenarioSpec) ([]api.ScenarioSpec, error) {
+	if len(scenario.Parameters) == 0 {
+		return []api.ScenarioSpec{scenario}, nil
+	}
+
+	names := slices.Sorted(maps.Keys(scenario.Parameters))
+	values := make([][]string, len(names))
+	for i, name := range names {
+		var err error
+		if values[i], err = formatParameterValues(scenario.Name, name, scenario.Parameters[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	expanded := []api.ScenarioSpec{}
+	for _, combination := range combinationsOf(values) {
+		parameterValues := make(map[string]string, len(names))
+		for i, name := range names {
+			parameterValues[name] = combination[i]
+		}
+		expanded = append(expanded, substituteParameters(scenario, names, parameterValues))
+	}
+
+	return expanded, nil
+}
+
+func formatParameterValues(scenario string, name string, values []interface{}) ([]string, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("parameter '%s' of scenario '%s' has no values", name, scenario)
+	}
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		switch value.(type) {
+		case string, bool, int, int64, uint64, float64:
+			formatted[i] = fmt.Sprint(value)
+		default:
+			return nil, fmt.Errorf("parameter '%s' of scenario '%s' has a non-scalar value '%v'", name, scenario, value)
+		}
+	}
+
+	return formatted, nil
+}
+
+// combinationsOf returns the cartesian product of the specified lists of values, with the last list varying fastest.
+func combinationsOf(values [][]string) [][]string {
+	combinations := [][]string{{}}
+	for _, list := range values {
+		next := make([][]string, 0, len(combinations)*len(list))
+		for _, combination := range combinations {
+			for _, value := range list {
+				next = append(next, append(slices.Clone(combination), value))
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+func substituteParameters(scenario api.ScenarioSpec, names []string, values map[string]string) api.ScenarioSpec {
+	pairs := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("${%s}", name), values[name])
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	expanded := scenario
+	expanded.Parameters = nil
+	expanded.ParameterValues = values
+	expanded.Name = replacer.Replace(scenario.Name)
+	if expanded.Name == scenario.Name {
+		expanded.Name = fmt.Sprintf("%s (%s)", scenario.Name, describeParameterValues(names, values))
+	}
+	expanded.WorkingDirectory = replacer.Replace(scenario.WorkingDirectory)
+
+	if scenario.Env != nil {
+		expanded.Env = make(map[string]string, len(scenario.Env))
+		for k, v := range scenario.Env {
+			expanded.Env[k] = replacer.Replace(v)
+		}
+	}
+
+	expanded.BeforeAll = substituteCommandParameters(scenario.BeforeAll, replacer)
+	expanded.AfterAll = substituteCommandParameters(scenario.AfterAll, replacer)
+	expanded.BeforeEach = substituteCommandParameters(scenario.BeforeEach, replacer)
+	expanded.AfterEach = substituteCommandParameters(scenario.AfterEach, replacer)
+	expanded.Command = substituteCommandParameters(scenario.Command, replacer)
+
+	return expanded
+}
+
+func substituteCommandParameters(command *api.CommandSpec, replacer *strings.Replacer) *api.CommandSpec {
+	if command == nil {
+		return nil
+	}
+
+	expanded := *command
+	expanded.WorkingDirectory = replacer.Replace(command.WorkingDirectory)
+	if command.Cmd != nil {
+		expanded.Cmd = make([]string, len(command.Cmd))
+		for i, arg := range command.Cmd {
+			expanded.Cmd[i] = replacer.Replace(arg)
+		}
+	}
+
+	return &expanded
+}
+
+func describeParameterValues(names []string, values map[string]string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%s", name, values[name])
+	}
+
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,167 @@
+package specs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSpecFromYamlDataWithParameters(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: build ${threads} ${mode}
+  workingDir: /tmp/${mode}
+  parameters:
+    threads: [1, 2, 4]
+    mode: [fast, safe]
+  env:
+    MODE: ${mode}
+  beforeAll:
+    cmd:
+    - setup
+    - ${mode}
+  command:
+    workingDir: /src/${threads}
+    shell: true
+    timeout: 1m
+    cmd:
+    - make
+    - -j${threads}
+- name: plain
+  command:
+    cmd:
+    - make
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, len(spec.Scenarios))
+	assert.Equal(
+		t,
+		[]string{"build 1 fast", "build 2 fast", "build 4 fast", "build 1 safe", "build 2 safe", "build 4 safe", "plain"},
+		scenarioNamesOf(spec),
+	)
+
+	scenario := spec.Scenarios[4]
+	assert.Nil(t, scenario.Parameters)
+	assert.Equal(t, map[string]string{"threads": "2", "mode": "safe"}, scenario.ParameterValues)
+	assert.Equal(t, "/tmp/safe", scenario.WorkingDirectory)
+	assert.Equal(t, map[string]string{"MODE": "safe"}, scenario.Env)
+	assert.Equal(t, []string{"setup", "safe"}, scenario.BeforeAll.Cmd)
+	assert.Equal(t, "/src/2", scenario.Command.WorkingDirectory)
+	assert.Equal(t, []string{"make", "-j2"}, scenario.Command.Cmd)
+	assert.Equal(t, api.DefaultShell, scenario.Command.Shell)
+	assert.Equal(t, api.Duration(time.Minute), scenario.Command.Timeout)
+	assert.Nil(t, scenario.AfterAll)
+
+	assert.Nil(t, spec.Scenarios[6].ParameterValues)
+}
+
+func TestLoadSpecFromYamlDataWithParametersAndShell(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: build ${mode}
+  parameters:
+    mode: [fast, safe]
+  command:
+    shell: true
+    cmd:
+    - make ${mode} | tee build.log
+`
+
+	spec, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	for i, mode := range []string{"fast", "safe"} {
+		assert.Equal(t, api.DefaultShell, spec.Scenarios[i].Command.Shell)
+		assert.Equal(t, []string{"make " + mode + " | tee build.log"}, spec.Scenarios[i].Command.Cmd)
+	}
+}
+
+func TestExpandParametersNamesScenariosAfterTheirValues(t *testing.T) {
+	spec := api.BenchmarkSpec{
+		Scenarios: []api.ScenarioSpec{
+			{
+				Name:       "build",
+				Parameters: map[string][]interface{}{"threads": {1, 2}, "cache": {true}},
+				Command:    &api.CommandSpec{Cmd: []string{"make"}},
+			},
+		},
+	}
+
+	expanded, err := expandParameters(spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"build (cache=true, threads=1)", "build (cache=true, threads=2)"}, scenarioNamesOf(expanded))
+}
+
+func TestExpandParametersDoesNotModifyTheOriginalSpec(t *testing.T) {
+	command := &api.CommandSpec{Cmd: []string{"make", "-j${threads}"}}
+	spec := api.BenchmarkSpec{
+		Scenarios: []api.ScenarioSpec{
+			{Name: "build", Parameters: map[string][]interface{}{"threads": {1, 2}}, Command: command},
+		},
+	}
+
+	_, err := expandParameters(spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"make", "-j${threads}"}, command.Cmd)
+}
+
+func TestExpandParametersWithInvalidParameters(t *testing.T) {
+	examples := map[string]map[string][]interface{}{
+		"no values":        {"threads": {}},
+		"non-scalar value": {"threads": {[]interface{}{1, 2}}},
+		"nil value":        {"threads": {nil}},
+	}
+
+	for description, parameters := range examples {
+		spec := api.BenchmarkSpec{
+			Scenarios: []api.ScenarioSpec{
+				{Name: "build", Parameters: parameters, Command: &api.CommandSpec{Cmd: []string{"make"}}},
+			},
+		}
+
+		_, err := expandParameters(spec)
+
+		assert.Error(t, err, description)
+	}
+}
+
+func TestExpandParametersWithDuplicateNames(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: build ${threads}
+  parameters:
+    threads: [1, 2]
+    mode: [fast, safe]
+  command:
+    cmd:
+    - make
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.Error(t, err)
+}
+
+func TestLoadJSONWithParameters(t *testing.T) {
+	spec, err := LoadSpec("../../test/data/spec_test_load_parameters.json")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sleep 1", "sleep 0.5"}, scenarioNamesOf(spec))
+	assert.Equal(t, []string{"sleep", "0.5"}, spec.Scenarios[1].Command.Cmd)
+}
+
+func scenarioNamesOf(spec api.BenchmarkSpec) []string {
+	names := []string{}
+	for _, scenario := range spec.Scenarios {
+		names = append(names, scenario.Name)
+	}
+
+	return names
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/sha1n/bert/api"
@@ -21,18 +23,12 @@ import (
 )
 
 // LoadSpec loads benchmark specs from the specified file.
+// Scenarios of included files are loaded, scenarios inherit the values of the scenarios they extend and the default values
+// of the spec, and templates are removed. Validation errors name the file each invalid scenario was loaded from.
 func LoadSpec(path string) (api.BenchmarkSpec, error) {
-	var unmarshalFn func([]byte, interface{}) error
-
 	slog.Info(fmt.Sprintf("Loading benchmark specs from '%s'...", path))
 
-	if strings.HasSuffix(path, ".json") {
-		unmarshalFn = json.Unmarshal
-	} else {
-		unmarshalFn = yaml.Unmarshal
-	}
-
-	return load(path, unmarshalFn)
+	return load(path)
 }
 
 // CreateSpecFrom creates a spec from the specified parameters.
@@ -63,11 +59,10 @@ func CreateSpecFrom(executions int, alternate bool, failFast bool, commands ...a
 }
 
 // LoadSpecFromYamlData loads a spec from the specified slice of bytes, assuming YAML data.
+// Relative include paths are relative to the current working directory.
 func LoadSpecFromYamlData(data []byte) (spec api.BenchmarkSpec, err error) {
-	err = yaml.Unmarshal(data, &spec)
-
-	if err == nil {
-		err = validate(spec)
+	if err = yaml.Unmarshal(data, &spec); err == nil {
+		spec, err = resolve(spec, "")
 	}
 
 	return spec, err
@@ -95,20 +90,66 @@ func save(spec api.BenchmarkSpec, wc io.WriteCloser) (err error) {
 	return err
 }
 
-func load(path string, unmarshal func([]byte, interface{}) error) (spec api.BenchmarkSpec, err error) {
+func load(path string) (spec api.BenchmarkSpec, err error) {
+	if err = unmarshalFile(path, &spec); err == nil {
+		spec, err = resolve(spec, path)
+	}
+
+	return spec, err
+}
+
+// unmarshalFile unmarshals the specified file into the specified value. Files with a '.json' suffix are parsed as JSON
+// and all other files as YAML.
+func unmarshalFile(path string, v interface{}) (err error) {
+	unmarshal := yaml.Unmarshal
+	if strings.HasSuffix(path, ".json") {
+		unmarshal = json.Unmarshal
+	}
+
 	var bytes []byte
 	if bytes, err = os.ReadFile(path); err == nil {
-		err = unmarshal(bytes, &spec)
+		err = unmarshal(bytes, v)
 	}
 
+	return err
+}
+
+// resolve builds the final list of scenarios of a spec that has been loaded from the specified path and validates it.
+// Included scenarios are loaded, then inheritance, defaults and parameters are applied, in this order.
+func resolve(spec api.BenchmarkSpec, path string) (api.BenchmarkSpec, error) {
+	scenarios, sources, err := includeScenarios(spec, path)
 	if err == nil {
-		err = validate(spec)
+		scenarios, sources, err = resolveExtends(scenarios, sources)
+	}
+	if err != nil {
+		return spec, err
+	}
+
+	applyDefaults(scenarios, spec.Defaults)
+	spec.Scenarios = scenarios
+	spec.Include = nil
+	spec.Defaults = nil
+
+	expandedSources := scenarioSources{}
+	for i := range spec.Scenarios {
+		for range expansionsOf(spec.Scenarios[i]) {
+			expandedSources = append(expandedSources, sources[i])
+		}
+	}
+
+	if spec, err = expandParameters(spec); err == nil {
+		err = validateScenarios(spec, expandedSources)
 	}
 
 	return spec, err
 }
 
-func validate(spec api.BenchmarkSpec) (err error) {
+func validate(spec api.BenchmarkSpec) error {
+	return validateScenarios(spec, nil)
+}
+
+// validateScenarios validates the specified spec. Errors of scenarios that were loaded from a file name the file.
+func validateScenarios(spec api.BenchmarkSpec, sources scenarioSources) (err error) {
 	v := validator.New()
 	english := en.New()
 	uni := ut.New(english, english)
@@ -118,18 +159,117 @@ func validate(spec api.BenchmarkSpec) (err error) {
 	if err = v.Struct(spec); err != nil {
 		var errstrings []string
 		errstrings = append(errstrings, "Invalid configuration:")
-		errstrings = append(errstrings, translateError(err, trans)...)
+		errstrings = append(errstrings, translateError(err, trans, spec, sources)...)
 		err = errors.New(strings.Join(errstrings, "\n\t- "))
 	}
 
+	if err == nil {
+		err = validateExecutions(spec, sources)
+	}
+	if err == nil {
+		err = validateOrder(spec)
+	}
+	if err == nil {
+		err = validateNames(spec, sources)
+	}
+	if err == nil {
+		err = validateRetries(spec, sources)
+	}
+	if err == nil {
+		err = validateExpectations(spec, sources)
+	}
+
 	return err
 }
 
-func translateError(err error, trans ut.Translator) (errs []string) {
+// validateOrder makes sure that the legacy alternate property doesn't contradict the execution order.
+func validateOrder(spec api.BenchmarkSpec) error {
+	if spec.Alternate && spec.Order != "" && spec.Order != api.OrderAlternate {
+		return fmt.Errorf("Invalid configuration:\n\t- alternate can't be combined with order '%s'", spec.Order)
+	}
+
+	return nil
+}
+
+// validateNames makes sure that scenario names are unique, since the stats of scenarios are collected by name.
+func validateNames(spec api.BenchmarkSpec, sources scenarioSources) error {
+	indexByName := map[string]int{}
+	for i, scenario := range spec.Scenarios {
+		if existing, exists := indexByName[scenario.Name]; exists {
+			return fmt.Errorf("Invalid configuration:\n\t- %s: the name is already used by %s", sources.describe(spec.Scenarios, i), sources.describe(spec.Scenarios, existing))
+		}
+		indexByName[scenario.Name] = i
+	}
+
+	return nil
+}
+
+// validateExecutions makes sure that the number of executions of every scenario is specified, either by the scenario,
+// by the benchmark or by adaptive execution.
+func validateExecutions(spec api.BenchmarkSpec, sources scenarioSources) error {
+	for i, scenario := range spec.Scenarios {
+		if spec.MaxExecutionsOf(scenario) == 0 {
+			return fmt.Errorf("Invalid configuration:\n\t- %s: the number of executions is not specified. specify 'executions' or 'adaptive' for the benchmark, or 'executions' for the scenario", sources.describe(spec.Scenarios, i))
+		}
+	}
+
+	return nil
+}
+
+// validateRetries makes sure that benchmarked commands are not retried, since retried executions would skew the stats.
+func validateRetries(spec api.BenchmarkSpec, sources scenarioSources) error {
+	for i, scenario := range spec.Scenarios {
+		if scenario.Command.Retries > 0 {
+			return fmt.Errorf("Invalid configuration:\n\t- %s: retries are only supported for hooks", sources.describe(spec.Scenarios, i))
+		}
+	}
+
+	return nil
+}
+
+// validateExpectations makes sure that the output patterns of all commands are valid regular expressions.
+func validateExpectations(spec api.BenchmarkSpec, sources scenarioSources) error {
+	hooks := map[string]*api.CommandSpec{"setup": spec.Setup, "teardown": spec.Teardown, "beforeRound": spec.BeforeRound, "afterRound": spec.AfterRound}
+	for name, cmd := range hooks {
+		if err := validateOutputPatterns(cmd); err != nil {
+			return fmt.Errorf("Invalid configuration:\n\t- %s: %s", name, err)
+		}
+	}
+	for i, scenario := range spec.Scenarios {
+		for _, cmd := range []*api.CommandSpec{scenario.BeforeAll, scenario.AfterAll, scenario.BeforeEach, scenario.AfterEach, scenario.Command} {
+			if err := validateOutputPatterns(cmd); err != nil {
+				return fmt.Errorf("Invalid configuration:\n\t- %s: %s", sources.describe(spec.Scenarios, i), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateOutputPatterns(cmd *api.CommandSpec) error {
+	if cmd == nil || cmd.Expect == nil {
+		return nil
+	}
+	for _, pattern := range []string{cmd.Expect.StdoutMatches, cmd.Expect.StdoutNotMatches, cmd.Expect.StderrMatches, cmd.Expect.StderrNotMatches} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid output pattern '%s'. %s", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+var scenarioNamespacePattern = regexp.MustCompile(`^BenchmarkSpec\.Scenarios\[(\d+)\]`)
+
+func translateError(err error, trans ut.Translator, spec api.BenchmarkSpec, sources scenarioSources) (errs []string) {
 	validatorErrs := err.(validator.ValidationErrors)
 	for _, e := range validatorErrs {
 		translatedErr := errors.New(e.Translate(trans))
 		slog.Debug(e.Error())
+		if match := scenarioNamespacePattern.FindStringSubmatch(e.Namespace()); match != nil {
+			i, _ := strconv.Atoi(match[1])
+			translatedErr = fmt.Errorf("%s: %w", sources.describe(spec.Scenarios, i), translatedErr)
+		}
 		errs = append(errs, translatedErr.Error())
 	}
 	return errs
@@ -5,7 +5,9 @@ import (
 	"math/rand"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sha1n/bert/api"
 	"github.com/sha1n/gommons/pkg/test"
@@ -123,6 +125,426 @@ scenarios:
 	assert.Error(t, err)
 }
 
+func TestLoadSpecFromYamlDataWithAdaptiveExecution(t *testing.T) {
+	example := `adaptive:
+  minExecutions: 5
+  maxExecutions: 100
+  targetRSE: 2
+  maxDuration: 1m
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.AdaptiveSpec{MinExecutions: 5, MaxExecutions: 100, TargetRSE: 2, MaxDuration: api.Duration(time.Minute)}, actual.Adaptive)
+	assert.Equal(t, 100, actual.MaxExecutions())
+}
+
+func TestLoadSpecFromYamlDataWithInvalidAdaptiveExecution(t *testing.T) {
+	example := `adaptive:
+  minExecutions: 5
+  maxExecutions: 2
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.Error(t, err)
+}
+
+func TestLoadSpecFromYamlDataWithoutExecutions(t *testing.T) {
+	example := `scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.Error(t, err)
+}
+
+func TestLoadSpecFromYamlDataWithScenarioExecutions(t *testing.T) {
+	example := `maxTime: 10m
+scenarios:
+- name: slow
+  executions: 3
+  maxDuration: 2m
+  command:
+    cmd:
+    - slow
+- name: fast
+  executions: 500
+  command:
+    cmd:
+    - fast
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.Duration(10*time.Minute), actual.MaxTime)
+	assert.Equal(t, 3, actual.MaxExecutionsOf(actual.Scenarios[0]))
+	assert.Equal(t, api.Duration(2*time.Minute), actual.Scenarios[0].MaxDuration)
+	assert.Equal(t, 500, actual.MaxExecutionsOf(actual.Scenarios[1]))
+	assert.Equal(t, 500, actual.MaxExecutions())
+}
+
+func TestLoadSpecFromYamlDataWithoutScenarioExecutions(t *testing.T) {
+	example := `scenarios:
+- name: slow
+  executions: 3
+  command:
+    cmd:
+    - slow
+- name: fast
+  command:
+    cmd:
+    - fast
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.ErrorContains(t, err, "the number of executions is not specified")
+}
+
+func TestLoadSpecFromYamlDataWithConcurrency(t *testing.T) {
+	example := `executions: 10
+concurrency: 4
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, actual.Concurrency)
+}
+
+func TestLoadSpecFromYamlDataWithNegativeConcurrency(t *testing.T) {
+	example := `executions: 10
+concurrency: -1
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.Error(t, err)
+}
+
+func TestLoadSpecFromYamlDataWithPercentiles(t *testing.T) {
+	example := `executions: 10
+percentiles: [50, 75, 95, 99, 99.9]
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{50, 75, 95, 99, 99.9}, actual.Percentiles)
+	assert.Equal(t, []float64{50, 75, 95, 99, 99.9}, actual.ReportPercentiles())
+}
+
+func TestLoadSpecFromYamlDataWithInvalidPercentiles(t *testing.T) {
+	for _, percentiles := range []string{"[0]", "[50, 100.1]", "[-1]"} {
+		example := fmt.Sprintf(`executions: 10
+percentiles: %s
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`, percentiles)
+
+		_, err := LoadSpecFromYamlData([]byte(example))
+
+		assert.Error(t, err, percentiles)
+	}
+}
+
+func TestReportPercentilesDefault(t *testing.T) {
+	assert.Equal(t, api.DefaultPercentiles, api.BenchmarkSpec{}.ReportPercentiles())
+}
+
+func TestLoadSpecFromYamlDataWithShell(t *testing.T) {
+	example := `executions: 10
+subtractShellOverhead: true
+scenarios:
+- name: default shell
+  command:
+    shell: true
+    cmd:
+    - ls | wc -l
+- name: bash
+  command:
+    shell: bash
+    cmd:
+    - ls | wc -l
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.True(t, actual.SubtractShellOverhead)
+	assert.Equal(t, api.DefaultShell, actual.Scenarios[0].Command.Shell)
+	assert.Equal(t, api.Shell("bash"), actual.Scenarios[1].Command.Shell)
+}
+
+func TestLoadSpecFromYamlDataWithErrorPolicies(t *testing.T) {
+	example := `executions: 10
+failFast: true
+scenarios:
+- name: test
+  maxErrors: 3
+  beforeAll:
+    retries: 2
+    cmd:
+    - setup
+  afterEach:
+    onError: ignore
+    cmd:
+    - cleanup
+  command:
+    onError: skip-scenario
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	scenario := actual.Scenarios[0]
+	assert.Equal(t, 3, scenario.MaxErrors)
+	assert.Equal(t, 2, scenario.BeforeAll.Retries)
+	assert.Equal(t, api.ErrorPolicyIgnore, actual.ErrorPolicy(scenario.AfterEach))
+	assert.Equal(t, api.ErrorPolicySkipScenario, actual.ErrorPolicy(scenario.Command))
+	assert.Equal(t, api.ErrorPolicyAbort, actual.ErrorPolicy(scenario.BeforeAll), "fail fast is expected to set the default policy")
+
+	actual.FailFast = false
+	assert.Equal(t, api.ErrorPolicyWarn, actual.ErrorPolicy(scenario.BeforeAll))
+}
+
+func TestLoadSpecFromYamlDataWithInvalidErrorPolicies(t *testing.T) {
+	for _, command := range []string{"onError: fail", "retries: -1", "retries: 1"} {
+		example := fmt.Sprintf(`executions: 10
+scenarios:
+- name: test
+  command:
+    %s
+    cmd:
+    - test
+`, command)
+
+		_, err := LoadSpecFromYamlData([]byte(example))
+
+		assert.Error(t, err, command)
+	}
+}
+
+func TestLoadSpecFromYamlDataWithExecutionOrder(t *testing.T) {
+	example := `executions: 10
+order: random
+seed: 1234
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.OrderRandom, actual.ExecutionOrder())
+	assert.Equal(t, int64(1234), actual.Seed)
+}
+
+func TestLoadSpecFromYamlDataWithInvalidExecutionOrder(t *testing.T) {
+	for _, order := range []string{"order: reverse", "order: shuffle\nalternate: true"} {
+		example := fmt.Sprintf(`executions: 10
+%s
+scenarios:
+- name: test
+  command:
+    cmd:
+    - test
+`, order)
+
+		_, err := LoadSpecFromYamlData([]byte(example))
+
+		assert.Error(t, err, order)
+	}
+}
+
+func TestLoadSpecFromYamlDataWithExpectations(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: lint
+  command:
+    expect:
+      exitCode: [0, 1]
+      stdoutMatches: "issues found"
+      stderrNotMatches: "(?i)panic"
+    cmd:
+    - lint
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.ExpectSpec{ExitCode: []int{0, 1}, StdoutMatches: "issues found", StderrNotMatches: "(?i)panic"}, actual.Scenarios[0].Command.Expect)
+}
+
+func TestLoadSpecFromYamlDataWithInvalidOutputPattern(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: lint
+  afterAll:
+    expect:
+      stderrMatches: "(unclosed"
+    cmd:
+    - cleanup
+  command:
+    cmd:
+    - lint
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.ErrorContains(t, err, "invalid output pattern '(unclosed'")
+}
+
+func TestLoadSpecFromYamlDataWithBenchmarkHooks(t *testing.T) {
+	example := `executions: 10
+alternate: true
+setup:
+  cmd:
+  - make
+  onError: abort
+teardown:
+  cmd:
+  - make clean
+beforeRound:
+  cmd:
+  - sync
+afterRound:
+  cmd:
+  - sleep 1
+scenarios:
+- name: run
+  command:
+    cmd:
+    - ./run
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"make"}, OnError: api.ErrorPolicyAbort}, actual.Setup)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"make clean"}}, actual.Teardown)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"sync"}}, actual.BeforeRound)
+	assert.Equal(t, &api.CommandSpec{Cmd: []string{"sleep 1"}}, actual.AfterRound)
+	assert.True(t, actual.HasRoundHooks())
+}
+
+func TestLoadSpecFromYamlDataWithInvalidBenchmarkHook(t *testing.T) {
+	example := `executions: 10
+setup:
+  expect:
+    stdoutMatches: "(unclosed"
+  cmd:
+  - make
+scenarios:
+- name: run
+  command:
+    cmd:
+    - ./run
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.ErrorContains(t, err, "setup: invalid output pattern '(unclosed'")
+
+	_, err = LoadSpecFromYamlData([]byte(strings.Replace(example, "cmd:\n  - make", "cmd: []", 1)))
+	assert.Error(t, err, "hooks without a command are expected to be rejected")
+}
+
+func TestLoadSpecFromYamlDataWithIsolation(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: pinned
+  isolation:
+    cpus: [2, 3]
+    nice: -5
+    ioNice: 0
+    rlimits:
+      nofile: 1024
+  command:
+    cmd:
+    - test
+`
+
+	actual, err := LoadSpecFromYamlData([]byte(example))
+
+	nice, ioNice := -5, 0
+	assert.NoError(t, err)
+	assert.Equal(t, &api.IsolationSpec{CPUs: []int{2, 3}, Nice: &nice, IONice: &ioNice, Rlimits: map[string]uint64{"nofile": 1024}}, actual.Scenarios[0].Isolation)
+}
+
+func TestLoadSpecFromYamlDataWithInvalidIsolation(t *testing.T) {
+	for _, isolation := range []string{"cpus: [-1]", "nice: 20", "ioNice: 8", "rlimits: {files: 10}"} {
+		example := fmt.Sprintf(`executions: 10
+scenarios:
+- name: pinned
+  isolation:
+    %s
+  command:
+    cmd:
+    - test
+`, isolation)
+
+		_, err := LoadSpecFromYamlData([]byte(example))
+
+		assert.ErrorContains(t, err, "scenario 'pinned'", isolation)
+	}
+}
+
+func TestLoadSpecFromYamlDataWithNegativeScenarioWarmup(t *testing.T) {
+	example := `executions: 10
+scenarios:
+- name: test
+  warmup: -3
+  command:
+    cmd:
+    - test
+`
+
+	_, err := LoadSpecFromYamlData([]byte(example))
+
+	assert.Error(t, err)
+}
+
 func TestCreateSpecFrom(t *testing.T) {
 	type args struct {
 		executions int
@@ -178,6 +600,8 @@ func testLoad(t *testing.T, filePath string) {
 }
 
 func expectedBenchmarkSpec() api.BenchmarkSpec {
+	zeroErrorRate, meanRegression := 0.0, 5.0
+
 	return api.BenchmarkSpec{
 		Executions: 10,
 		Alternate:  true,
@@ -209,6 +633,14 @@ func expectedBenchmarkSpec() api.BenchmarkSpec {
 				Command: &api.CommandSpec{
 					Cmd: []string{"sleep", "0"},
 				},
+				Thresholds: &api.ThresholdsSpec{
+					Mean:      api.Duration(2 * time.Second),
+					P90:       api.Duration(3 * time.Second),
+					ErrorRate: &zeroErrorRate,
+					Regression: &api.RegressionThresholdsSpec{
+						Mean: &meanRegression,
+					},
+				},
 			},
 		},
 	}
@@ -0,0 +1,216 @@
+package thresholds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sha1n/bert/api"
+)
+
+// Metric the name of an asserted metric
+type Metric = string
+
+const (
+	// MetricMean mean perceived time
+	MetricMean Metric = "mean"
+	// MetricMedian median perceived time
+	MetricMedian Metric = "median"
+	// MetricP90 90th percentile of perceived time
+	MetricP90 Metric = "p90"
+	// MetricMax max perceived time
+	MetricMax Metric = "max"
+	// MetricErrorRate error rate in percents
+	MetricErrorRate Metric = "errorRate"
+)
+
+// errNoResults the error of checks of scenarios that have no results, e.g. because they were never executed
+var errNoResults = errors.New("no results, the scenario has not been executed")
+
+// Check the result of a single threshold assertion.
+//
+// Absolute duration checks hold nanoseconds, error rate checks hold percents and
+// relative checks hold the change in percents compared to the baseline.
+type Check struct {
+	ID       api.ID
+	Metric   Metric
+	Relative bool
+	Limit    float64
+	Actual   float64
+	Err      error
+}
+
+// Passed returns true if the asserted metric is within its limit
+func (c Check) Passed() bool {
+	return c.Err == nil && c.Actual <= c.Limit
+}
+
+// String returns a human readable description of this check
+func (c Check) String() string {
+	if c.Err != nil {
+		return fmt.Sprintf("'%s' %s: %s", c.ID, c.Metric, c.Err)
+	}
+
+	return fmt.Sprintf("'%s' %s: %s (limit %s)", c.ID, c.Metric, c.FormatActual(), c.FormatLimit())
+}
+
+// FormatActual formats the actual value of this check for report rendering
+func (c Check) FormatActual() string {
+	return c.format(c.Actual)
+}
+
+// FormatLimit formats the limit of this check for report rendering
+func (c Check) FormatLimit() string {
+	return c.format(c.Limit)
+}
+
+func (c Check) format(value float64) string {
+	switch {
+	case c.Relative:
+		return fmt.Sprintf("%+.2f%%", value)
+	case c.Metric == MetricErrorRate:
+		return fmt.Sprintf("%.2f%%", value)
+	case value >= float64(time.Millisecond):
+		return time.Duration(value).Round(time.Microsecond).String()
+	default:
+		return time.Duration(value).String()
+	}
+}
+
+// Checks the results of all threshold assertions of a benchmark
+type Checks []Check
+
+// Failed returns true if any of the checks of the specified scenario metric failed.
+func (checks Checks) Failed(id api.ID, metric Metric) bool {
+	for _, check := range checks {
+		if check.ID == id && check.Metric == metric && !check.Passed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns an error describing all failed checks, or nil if all checks passed.
+func (checks Checks) Err() error {
+	var failed []string
+	for _, check := range checks {
+		if !check.Passed() {
+			failed = append(failed, check.String())
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &FailedError{
+		message: fmt.Sprintf("%d threshold check(s) failed:\n\t- %s", len(failed), strings.Join(failed, "\n\t- ")),
+	}
+}
+
+// FailedError an error returned when one or more threshold checks fail
+type FailedError struct {
+	message string
+}
+
+func (e *FailedError) Error() string {
+	return e.message
+}
+
+// Evaluate evaluates the thresholds of all the scenarios of the specified spec against the specified summary.
+// Regression thresholds are evaluated against the specified baseline summary, which might be nil if the spec
+// defines no regression thresholds. The checks of scenarios that have no results in the summary fail.
+func Evaluate(spec api.BenchmarkSpec, summary api.Summary, baseline api.Summary) Checks {
+	checks := Checks{}
+	for _, scenario := range spec.Scenarios {
+		thresholds := scenario.Thresholds
+		if thresholds == nil {
+			continue
+		}
+
+		id := scenario.ID()
+		stats := summary.PerceivedTimeStats(id)
+		checks = appendDurationCheck(checks, id, MetricMean, thresholds.Mean, resultMetric(stats, api.Stats.Mean))
+		checks = appendDurationCheck(checks, id, MetricMedian, thresholds.Median, resultMetric(stats, api.Stats.Median))
+		checks = appendDurationCheck(checks, id, MetricP90, thresholds.P90, resultMetric(stats, p90))
+		checks = appendDurationCheck(checks, id, MetricMax, thresholds.Max, resultMetric(stats, api.Stats.Max))
+
+		if thresholds.ErrorRate != nil {
+			check := Check{ID: id, Metric: MetricErrorRate, Limit: *thresholds.ErrorRate, Err: errNoResults}
+			if stats != nil {
+				check.Actual, check.Err = stats.ErrorRate()*100, nil
+			}
+			checks = append(checks, check)
+		}
+
+		if regression := thresholds.Regression; regression != nil {
+			var baselineStats api.Stats
+			if baseline != nil {
+				baselineStats = baseline.PerceivedTimeStats(id)
+			}
+
+			checks = appendRegressionCheck(checks, id, MetricMean, regression.Mean, resultMetric(stats, api.Stats.Mean), baselineMetric(baselineStats, api.Stats.Mean))
+			checks = appendRegressionCheck(checks, id, MetricMedian, regression.Median, resultMetric(stats, api.Stats.Median), baselineMetric(baselineStats, api.Stats.Median))
+			checks = appendRegressionCheck(checks, id, MetricP90, regression.P90, resultMetric(stats, p90), baselineMetric(baselineStats, p90))
+		}
+	}
+
+	return checks
+}
+
+func appendDurationCheck(checks Checks, id api.ID, metric Metric, limit api.Duration, actualFn func() (time.Duration, error)) Checks {
+	if limit <= 0 {
+		return checks
+	}
+
+	actual, err := actualFn()
+
+	return append(checks, Check{ID: id, Metric: metric, Limit: float64(limit), Actual: float64(actual), Err: err})
+}
+
+func appendRegressionCheck(checks Checks, id api.ID, metric Metric, limit *float64, actualFn func() (time.Duration, error), baselineFn func() (time.Duration, error)) Checks {
+	if limit == nil {
+		return checks
+	}
+
+	check := Check{ID: id, Metric: metric, Relative: true, Limit: *limit}
+
+	var actual, baseline time.Duration
+	if actual, check.Err = actualFn(); check.Err == nil {
+		if baseline, check.Err = baselineFn(); check.Err == nil {
+			if baseline <= 0 {
+				check.Err = errors.New("baseline value is zero")
+			} else {
+				check.Actual = (float64(actual)/float64(baseline) - 1) * 100
+			}
+		}
+	}
+
+	return append(checks, check)
+}
+
+func baselineMetric(stats api.Stats, metricFn func(api.Stats) (time.Duration, error)) func() (time.Duration, error) {
+	return func() (time.Duration, error) {
+		if stats == nil {
+			return 0, errors.New("no baseline results")
+		}
+
+		return metricFn(stats)
+	}
+}
+
+func resultMetric(stats api.Stats, metricFn func(api.Stats) (time.Duration, error)) func() (time.Duration, error) {
+	return func() (time.Duration, error) {
+		if stats == nil {
+			return 0, errNoResults
+		}
+
+		return metricFn(stats)
+	}
+}
+
+func p90(stats api.Stats) (time.Duration, error) {
+	return stats.Percentile(90)
+}
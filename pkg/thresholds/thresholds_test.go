@@ -0,0 +1,137 @@
+package thresholds
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sha1n/bert/api"
+	"github.com/sha1n/bert/pkg/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+const scenarioID = "scenario"
+
+func TestEvaluateWithNoThresholds(t *testing.T) {
+	spec := aSpecWith(nil)
+
+	checks := Evaluate(spec, aSummaryWith(time.Second), nil)
+
+	assert.Empty(t, checks)
+	assert.NoError(t, checks.Err())
+}
+
+func TestEvaluateAbsoluteThresholds(t *testing.T) {
+	spec := aSpecWith(&api.ThresholdsSpec{
+		Mean:      api.Duration(2 * time.Second),
+		Max:       api.Duration(2 * time.Second),
+		ErrorRate: aFloat(0),
+	})
+
+	checks := Evaluate(spec, aSummaryWith(time.Second, 3*time.Second), nil)
+
+	assert.Len(t, checks, 3)
+	assert.False(t, checks.Failed(scenarioID, MetricMean))
+	assert.True(t, checks.Failed(scenarioID, MetricMax))
+	assert.False(t, checks.Failed(scenarioID, MetricErrorRate))
+	assert.Error(t, checks.Err())
+	assert.Contains(t, checks.Err().Error(), "'scenario' max: 3s (limit 2s)")
+}
+
+func TestEvaluateErrorRateThreshold(t *testing.T) {
+	spec := aSpecWith(&api.ThresholdsSpec{ErrorRate: aFloat(10)})
+	summary := exec.NewSummary(map[api.ID][]api.Trace{
+		scenarioID: {aTrace(time.Second, nil), aTrace(time.Second, errors.New("failed"))},
+	})
+
+	checks := Evaluate(spec, summary, nil)
+
+	assert.True(t, checks.Failed(scenarioID, MetricErrorRate))
+	assert.Equal(t, 50.0, checks[0].Actual)
+}
+
+func TestEvaluateRegressionThresholds(t *testing.T) {
+	spec := aSpecWith(&api.ThresholdsSpec{
+		Regression: &api.RegressionThresholdsSpec{
+			Mean:   aFloat(5),
+			Median: aFloat(20),
+		},
+	})
+
+	checks := Evaluate(spec, aSummaryWith(110*time.Millisecond), aSummaryWith(100*time.Millisecond))
+
+	assert.Len(t, checks, 2)
+	assert.True(t, checks.Failed(scenarioID, MetricMean))
+	assert.False(t, checks.Failed(scenarioID, MetricMedian))
+	assert.InDelta(t, 10.0, checks[0].Actual, 0.0001)
+	assert.Equal(t, "+10.00%", checks[0].FormatActual())
+}
+
+func TestEvaluateRegressionThresholdsWithMissingBaseline(t *testing.T) {
+	spec := aSpecWith(&api.ThresholdsSpec{
+		Regression: &api.RegressionThresholdsSpec{Mean: aFloat(5)},
+	})
+
+	checks := Evaluate(spec, aSummaryWith(time.Second), exec.NewSummary(map[api.ID][]api.Trace{}))
+
+	assert.Len(t, checks, 1)
+	assert.Error(t, checks[0].Err)
+	assert.True(t, checks.Failed(scenarioID, MetricMean))
+}
+
+func TestEvaluateThresholdsOfScenarioWithoutResults(t *testing.T) {
+	spec := aSpecWith(&api.ThresholdsSpec{
+		Mean:       api.Duration(time.Second),
+		ErrorRate:  aFloat(10),
+		Regression: &api.RegressionThresholdsSpec{Median: aFloat(5)},
+	})
+
+	checks := Evaluate(spec, exec.NewSummary(map[api.ID][]api.Trace{}), aSummaryWith(time.Second))
+
+	assert.Len(t, checks, 3)
+	assert.True(t, checks.Failed(scenarioID, MetricMean))
+	assert.True(t, checks.Failed(scenarioID, MetricErrorRate))
+	assert.True(t, checks.Failed(scenarioID, MetricMedian))
+	assert.ErrorIs(t, checks[0].Err, errNoResults)
+	assert.Contains(t, checks.Err().Error(), "'scenario' mean: no results, the scenario has not been executed")
+}
+
+func aSpecWith(thresholds *api.ThresholdsSpec) api.BenchmarkSpec {
+	return api.BenchmarkSpec{
+		Executions: 1,
+		Scenarios: []api.ScenarioSpec{
+			{Name: scenarioID, Command: &api.CommandSpec{Cmd: []string{"cmd"}}, Thresholds: thresholds},
+		},
+	}
+}
+
+func aSummaryWith(durations ...time.Duration) api.Summary {
+	traces := []api.Trace{}
+	for _, d := range durations {
+		traces = append(traces, aTrace(d, nil))
+	}
+
+	return exec.NewSummary(map[api.ID][]api.Trace{scenarioID: traces})
+}
+
+func aFloat(f float64) *float64 {
+	return &f
+}
+
+type fakeTrace struct {
+	perceivedTime time.Duration
+	err           error
+}
+
+func aTrace(perceivedTime time.Duration, err error) api.Trace {
+	return fakeTrace{perceivedTime: perceivedTime, err: err}
+}
+
+func (t fakeTrace) ID() string                       { return scenarioID }
+func (t fakeTrace) PerceivedTime() time.Duration     { return t.perceivedTime }
+func (t fakeTrace) SystemCPUTime() time.Duration     { return 0 }
+func (t fakeTrace) UserCPUTime() time.Duration       { return 0 }
+func (t fakeTrace) ResourceUsage() api.ResourceUsage { return api.ResourceUsage{} }
+func (t fakeTrace) Concurrency() int                 { return 1 }
+func (t fakeTrace) Wait() time.Duration              { return 0 }
+func (t fakeTrace) Error() error                     { return t.err }
@@ -37,6 +37,21 @@ func (l LoggingProgressListener) OnScenarioEnd(id api.ID) {
 	slog.Info(fmt.Sprintf("[%s] finished", yellow.Sprint(id)))
 }
 
+// OnWarmupStart logs an info message with the specified ID
+func (l LoggingProgressListener) OnWarmupStart(id api.ID) {
+	slog.Info(fmt.Sprintf("[%s] warming up...", yellow.Sprint(id)))
+}
+
+// OnWarmupEnd logs an info message with the specified ID
+func (l LoggingProgressListener) OnWarmupEnd(id api.ID) {
+	slog.Info(fmt.Sprintf("[%s] warmup finished", yellow.Sprint(id)))
+}
+
+// OnExecutionsEstimate logs an info message with the specified ID and executions estimate
+func (l LoggingProgressListener) OnExecutionsEstimate(id api.ID, executions int) {
+	slog.Info(fmt.Sprintf("[%s] estimated executions: %d", yellow.Sprint(id), executions))
+}
+
 // OnError logs an error message with the specified ID and error details
 func (l LoggingProgressListener) OnError(id api.ID, err error) {
 	slog.Error(fmt.Sprintf("[%s] error: %v", yellow.Sprint(id), err))
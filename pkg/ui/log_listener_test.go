@@ -57,6 +57,41 @@ func TestLogProgressListener_OnScenarioEnd(t *testing.T) {
 	assert.Contains(t, buf.String(), expectedScenarioID)
 }
 
+func TestLogProgressListener_OnWarmupStart(t *testing.T) {
+	l := NewLoggingProgressListener()
+	buf, restore := interceptSlog()
+	defer restore()
+	expectedScenarioID := test.RandomString()
+
+	l.OnWarmupStart(expectedScenarioID)
+
+	assert.Contains(t, buf.String(), expectedScenarioID)
+}
+
+func TestLogProgressListener_OnWarmupEnd(t *testing.T) {
+	l := NewLoggingProgressListener()
+	buf, restore := interceptSlog()
+	defer restore()
+	expectedScenarioID := test.RandomString()
+
+	l.OnWarmupEnd(expectedScenarioID)
+
+	assert.Contains(t, buf.String(), expectedScenarioID)
+}
+
+func TestLogProgressListener_OnExecutionsEstimate(t *testing.T) {
+	l := NewLoggingProgressListener()
+	buf, restore := interceptSlog()
+	defer restore()
+	expectedScenarioID := test.RandomString()
+
+	l.OnExecutionsEstimate(expectedScenarioID, 42)
+
+	actual := buf.String()
+	assert.Contains(t, actual, expectedScenarioID)
+	assert.Contains(t, actual, "42")
+}
+
 func TestLogProgressListener_OnError(t *testing.T) {
 	l := NewLoggingProgressListener()
 	buf, restore := interceptSlog()
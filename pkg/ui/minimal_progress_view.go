@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/sha1n/bert/api"
@@ -22,6 +23,8 @@ type MinimalProgressView struct {
 	alternate        bool
 	cancelHandlers   []context.CancelFunc
 	stderr           io.Writer
+	concurrency      int
+	mx               *sync.Mutex
 }
 
 // NewMinimalProgressView creates a new MinimalProgressView for the specified benchmark spec
@@ -35,23 +38,28 @@ func NewMinimalProgressView(spec api.BenchmarkSpec, termDimensionsFn func() (int
 	for _, scenario := range spec.Scenarios {
 		progressInfoByID[scenario.ID()] = &minimalProgressInfo{
 			notificationWriter: etaRow,
-			expectedExecutions: spec.Executions,
+			expectedExecutions: spec.MaxExecutionsOf(scenario),
 		}
 	}
 
 	return &MinimalProgressView{
 		matrix:           matrix,
 		progressInfoByID: progressInfoByID,
-		eta:              newEtaInfo(etaRow, spec.Alternate, termDimensionsFn),
+		eta:              newEtaInfo(etaRow, spec.ExecutionOrder() != api.OrderSequential, termDimensionsFn),
 		cursor:           termite.NewCursor(ioc.StdoutWriter),
-		alternate:        spec.Alternate,
+		alternate:        spec.ExecutionOrder() != api.OrderSequential,
 		cancelHandlers:   cancelHandlers,
 		stderr:           ioc.StderrWriter,
+		concurrency:      max(spec.Concurrency, 1),
+		mx:               &sync.Mutex{},
 	}
 }
 
 // OnBenchmarkStart starts updating view components in the background.
 func (l *MinimalProgressView) OnBenchmarkStart() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	if l.started {
 		panic(errors.New("already started"))
 	}
@@ -62,6 +70,9 @@ func (l *MinimalProgressView) OnBenchmarkStart() {
 
 // OnBenchmarkEnd stops all view component updates.
 func (l *MinimalProgressView) OnBenchmarkEnd() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	if !l.started {
 		panic(errors.New("not started"))
 	}
@@ -80,26 +91,57 @@ func (l *MinimalProgressView) OnBenchmarkEnd() {
 	}
 }
 
-// OnScenarioStart does nothing
+// OnScenarioStart starts the execution clock of a scenario run
 func (l *MinimalProgressView) OnScenarioStart(id api.ID) {
-	progressInfo := l.progressInfoByID[id]
-	progressInfo.lastStartTime = time.Now()
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.progressInfoByID[id].startExecution()
 }
 
 // OnScenarioEnd update relevant view components
 func (l *MinimalProgressView) OnScenarioEnd(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
 	defer l.matrix.UpdateTerminal(true)
 
 	progressInfo := l.progressInfoByID[id]
-	elapsed := time.Since(progressInfo.lastStartTime)
+	elapsed := progressInfo.endExecution()
 	progressInfo.mean = progressInfo.calculateNewApproxMean(elapsed)
 	progressInfo.executions++
 
 	l.eta.update(l.calculateETA(), id)
 }
 
+// OnWarmupStart displays a warmup notification in place of the ETA
+func (l *MinimalProgressView) OnWarmupStart(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	defer l.matrix.UpdateTerminal(true)
+	l.eta.updateWarmup(id)
+}
+
+// OnWarmupEnd restarts the execution clock, so that warmup time is not accounted for in ETA calculations.
+func (l *MinimalProgressView) OnWarmupEnd(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.progressInfoByID[id].restartExecutions()
+}
+
+// OnExecutionsEstimate updates the number of expected executions used for ETA calculations
+func (l *MinimalProgressView) OnExecutionsEstimate(id api.ID, executions int) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.progressInfoByID[id].expectedExecutions = executions
+}
+
 // OnError prints a corresponding error message in the progress info area
 func (l *MinimalProgressView) OnError(id api.ID, err error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	progressInfo := l.progressInfoByID[id]
 	progressInfo.lastError = err
 }
@@ -116,7 +158,7 @@ func (l *MinimalProgressView) calculateETA() time.Duration {
 		eta += l.progressInfoByID[id].calculateETA()
 	}
 
-	return eta
+	return eta / time.Duration(l.concurrency)
 }
 
 func (l *MinimalProgressView) hideCursor() (restore func()) {
@@ -152,6 +194,12 @@ func (eta etaInfo) update(dur time.Duration, id api.ID) {
 	}
 }
 
+func (eta etaInfo) updateWarmup(id api.ID) {
+	termWidth, _ := eta.termDimensionsFn()
+	terminalScaledScenarioName := termite.TruncateString(id, termWidth-36)
+	eta.updateStringRaw("%11s: %-9s %s: %s", "---> ETA", "warmup", "> SCENARIO", yellow.Sprint(terminalScaledScenarioName))
+}
+
 func (eta etaInfo) clear() {
 	_, _ = io.WriteString(eta.writer, termite.TermControlEraseLine)
 }
@@ -166,7 +214,7 @@ func (eta etaInfo) updateStringRaw(format string, args ...interface{}) {
 
 type minimalProgressInfo struct {
 	notificationWriter io.Writer
-	lastStartTime      time.Time
+	startTimes         []time.Time
 	executions         int
 	expectedExecutions int
 	mean               time.Duration
@@ -177,6 +225,32 @@ func (pi minimalProgressInfo) writeNotification(msg string) {
 	_, _ = io.WriteString(pi.notificationWriter, fmt.Sprintf("%11s  %s", "", msg))
 }
 
+// startExecution starts the clock of a new execution.
+func (pi *minimalProgressInfo) startExecution() {
+	pi.startTimes = append(pi.startTimes, time.Now())
+}
+
+// endExecution stops the clock of the earliest execution in progress and returns its elapsed time.
+// Concurrent executions are assumed to end in the order they started, which is good enough for ETA calculations.
+func (pi *minimalProgressInfo) endExecution() time.Duration {
+	if len(pi.startTimes) == 0 {
+		return 0
+	}
+
+	startTime := pi.startTimes[0]
+	pi.startTimes = pi.startTimes[1:]
+
+	return time.Since(startTime)
+}
+
+// restartExecutions restarts the clock of all executions in progress.
+func (pi *minimalProgressInfo) restartExecutions() {
+	now := time.Now()
+	for i := range pi.startTimes {
+		pi.startTimes[i] = now
+	}
+}
+
 func (pi minimalProgressInfo) calculateETA() time.Duration {
 	return time.Duration(int64(pi.expectedExecutions-pi.executions) * int64(pi.mean))
 }
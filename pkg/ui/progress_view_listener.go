@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -37,7 +38,7 @@ var (
 // the terminal visually and in place.
 // Combining this implementation with other Stdout writers might break the terminal view.
 //
-// This implementation is intended to be called from a single thread and is not thread-safe!
+// This implementation is safe for concurrent use.
 // This implementation requires a terminal to be attached to this process. If no terminal is
 // attached NewProgressView will panic.
 type ProgressView struct {
@@ -50,6 +51,8 @@ type ProgressView struct {
 	ended            bool
 	eta              etaInfo
 	stderr           io.Writer
+	concurrency      int
+	mx               *sync.Mutex
 }
 
 // NewProgressView creates a new ProgressView for the specified benchmark spec
@@ -79,7 +82,8 @@ func NewProgressView(spec api.BenchmarkSpec, termDimensionsFn func() (int, int),
 
 	for i, scenario := range spec.Scenarios {
 		formatter := newProgressBarFormatter()
-		pBar := termite.NewProgressBar(rows[nextProgressBarRowIndex], spec.Executions, termWidthFn, 59, formatter)
+		maxExecutions := spec.MaxExecutionsOf(scenario)
+		pBar := termite.NewProgressBar(rows[nextProgressBarRowIndex], maxExecutions, termWidthFn, 59, formatter)
 		terminalScaledScenarioName := termite.TruncateString(scenario.Name, termWidthFn()-14)
 		rows[nextProgressBarRowIndex-1].Update(fmt.Sprintf("%11s: %s", "SCENARIO", yellow.Sprint(terminalScaledScenarioName)))
 		notificationsRowIndex := nextProgressBarRowIndex + 1
@@ -91,9 +95,10 @@ func NewProgressView(spec api.BenchmarkSpec, termDimensionsFn func() (int, int),
 		progressInfoByID[scenario.ID()] = &progressInfo{
 			minimalProgressInfo: minimalProgressInfo{
 				notificationWriter: rows[notificationsRowIndex],
-				expectedExecutions: spec.Executions,
+				expectedExecutions: maxExecutions,
 			},
 			tick:      tick,
+			maxTicks:  maxExecutions,
 			formatter: formatter,
 		}
 		cancelHandlers[i] = cancel
@@ -101,16 +106,21 @@ func NewProgressView(spec api.BenchmarkSpec, termDimensionsFn func() (int, int),
 
 	return &ProgressView{
 		matrix:           matrix,
-		eta:              newEtaInfo(rows[len(rows)-1], spec.Alternate, termDimensionsFn),
+		eta:              newEtaInfo(rows[len(rows)-1], spec.ExecutionOrder() != api.OrderSequential, termDimensionsFn),
 		progressInfoByID: progressInfoByID,
 		cancelHandlers:   cancelHandlers,
 		cursor:           termite.NewCursor(ioc.StdoutWriter),
 		stderr:           ioc.StderrWriter,
+		concurrency:      max(spec.Concurrency, 1),
+		mx:               &sync.Mutex{},
 	}
 }
 
 // OnBenchmarkStart starts updating view components in the background.
 func (l *ProgressView) OnBenchmarkStart() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	if l.started {
 		panic(errors.New("already started"))
 	}
@@ -131,6 +141,9 @@ func (l *ProgressView) OnBenchmarkStart() {
 
 // OnBenchmarkEnd stops all view component updates.
 func (l *ProgressView) OnBenchmarkEnd() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	if !l.started {
 		panic(errors.New("not started"))
 	}
@@ -149,27 +162,64 @@ func (l *ProgressView) OnBenchmarkEnd() {
 	}
 }
 
-// OnScenarioStart does nothing
+// OnScenarioStart starts the execution clock of a scenario run
 func (l *ProgressView) OnScenarioStart(id api.ID) {
-	progressInfo := l.progressInfoByID[id]
-	progressInfo.lastStartTime = time.Now()
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.progressInfoByID[id].startExecution()
 }
 
 // OnScenarioEnd update relevant view components
 func (l *ProgressView) OnScenarioEnd(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
 	defer l.matrix.UpdateTerminal(true)
+
 	progressInfo := l.progressInfoByID[id]
-	elapsed := time.Since(progressInfo.lastStartTime)
+	elapsed := progressInfo.endExecution()
 	progressInfo.mean = progressInfo.calculateNewApproxMean(elapsed)
 	progressInfo.executions++
 
-	progressInfo.tick(fmt.Sprintf("%-9s", formatDuration(progressInfo.mean)))
+	progressInfo.tickProgress(fmt.Sprintf("%-9s", formatDuration(progressInfo.mean)))
 
 	l.eta.update(l.calculateETA(), id)
 }
 
+// OnExecutionsEstimate updates the number of expected executions used for progress and ETA calculations
+func (l *ProgressView) OnExecutionsEstimate(id api.ID, executions int) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.progressInfoByID[id].expectedExecutions = executions
+}
+
+// OnWarmupStart displays a warmup notification in the progress info area
+func (l *ProgressView) OnWarmupStart(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	defer l.matrix.UpdateTerminal(true)
+	l.progressInfoByID[id].writeNotification(hiYellow.Sprint("warming up..."))
+}
+
+// OnWarmupEnd clears the warmup notification and restarts the execution clock, so that warmup
+// time is not accounted for in ETA calculations.
+func (l *ProgressView) OnWarmupEnd(id api.ID) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	defer l.matrix.UpdateTerminal(true)
+	progressInfo := l.progressInfoByID[id]
+	progressInfo.writeNotification("")
+	progressInfo.restartExecutions()
+}
+
 // OnError prints a corresponding error message in the progress info area
 func (l *ProgressView) OnError(id api.ID, err error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
 	defer l.matrix.UpdateTerminal(true)
 	progressInfo := l.progressInfoByID[id]
 	progressInfo.formatter.color = progressBarErrorColorEscalator[l.progressInfoByID[id].formatter.color]
@@ -188,7 +238,7 @@ func (l *ProgressView) calculateETA() time.Duration {
 		eta += l.progressInfoByID[id].calculateETA()
 	}
 
-	return eta
+	return eta / time.Duration(l.concurrency)
 }
 
 func (l *ProgressView) hideCursor() (restore func()) {
@@ -246,9 +296,25 @@ type progressInfo struct {
 	minimalProgressInfo
 
 	tick      termite.TickMessageFn
+	ticks     int
+	maxTicks  int
 	formatter *progressBarFormatter
 }
 
+// tickProgress ticks the progress bar to the ratio between the executions so far and the expected executions.
+// When the number of expected executions is fixed, the progress bar is ticked exactly once per execution.
+func (pi *progressInfo) tickProgress(message string) {
+	expectedTicks := pi.maxTicks
+	if pi.executions < pi.expectedExecutions {
+		expectedTicks = pi.executions * pi.maxTicks / pi.expectedExecutions
+	}
+
+	for pi.ticks < expectedTicks {
+		pi.tick(message)
+		pi.ticks++
+	}
+}
+
 func formatDuration(value time.Duration) string {
 	if value.Hours() >= 1 {
 		return fmt.Sprintf("%c %.1fh", approxSymbol, value.Hours())
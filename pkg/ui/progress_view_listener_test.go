@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -49,6 +50,105 @@ func TestProgressViewOutput(t *testing.T) {
 	progView.OnBenchmarkEnd()
 }
 
+func TestProgressViewWarmupOutput(t *testing.T) {
+	ctx := api.NewIOContext()
+	ctx.Tty = true
+	ctx.StdoutWriter = new(bytes.Buffer)
+	ctx.StderrWriter = new(bytes.Buffer)
+	spec := aSpec(false, 1)
+	scenarioID := spec.Scenarios[0].ID()
+
+	progView := NewProgressView(spec, fakeTermDimensions, ctx).(*ProgressView)
+
+	progView.OnBenchmarkStart()
+	progView.OnScenarioStart(scenarioID)
+	startTime := progView.progressInfoByID[scenarioID].startTimes[0]
+
+	progView.OnWarmupStart(scenarioID)
+	stdoutEventuallyContains(t, "warming up...", ctx)
+
+	time.Sleep(time.Millisecond)
+	progView.OnWarmupEnd(scenarioID)
+	assert.True(t, progView.progressInfoByID[scenarioID].startTimes[0].After(startTime), "warmup time is not expected to affect the ETA")
+
+	progView.OnScenarioEnd(scenarioID)
+	progView.OnBenchmarkEnd()
+}
+
+func TestProgressViewAdaptiveOutput(t *testing.T) {
+	ctx := api.NewIOContext()
+	ctx.Tty = true
+	ctx.StdoutWriter = new(bytes.Buffer)
+	ctx.StderrWriter = new(bytes.Buffer)
+	spec := aSpec(false, 0)
+	spec.Adaptive = &api.AdaptiveSpec{MinExecutions: 2, MaxExecutions: 100}
+	scenarioID := spec.Scenarios[0].ID()
+
+	progView := NewProgressView(spec, fakeTermDimensions, ctx).(*ProgressView)
+	progressInfo := progView.progressInfoByID[scenarioID]
+	assert.Equal(t, 100, progressInfo.expectedExecutions)
+
+	progView.OnBenchmarkStart()
+
+	progView.OnScenarioStart(scenarioID)
+	progView.OnExecutionsEstimate(scenarioID, 10)
+	progView.OnScenarioEnd(scenarioID)
+	assert.Equal(t, 10, progressInfo.ticks, "1 of 10 expected executions is 10% of the progress bar")
+
+	progView.OnScenarioStart(scenarioID)
+	progView.OnExecutionsEstimate(scenarioID, 2)
+	progView.OnScenarioEnd(scenarioID)
+	assert.Equal(t, 100, progressInfo.ticks, "the progress bar is expected to be full when the scenario is done")
+	assert.Equal(t, time.Duration(0), progressInfo.calculateETA())
+
+	progView.OnBenchmarkEnd()
+}
+
+func TestProgressViewConcurrentOutput(t *testing.T) {
+	ctx := api.NewIOContext()
+	ctx.Tty = true
+	ctx.StdoutWriter = new(bytes.Buffer)
+	ctx.StderrWriter = new(bytes.Buffer)
+	spec := aSpec(false, 10)
+	spec.Concurrency = 5
+	scenarioID := spec.Scenarios[0].ID()
+
+	progView := NewProgressView(spec, fakeTermDimensions, ctx).(*ProgressView)
+	progView.OnBenchmarkStart()
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < spec.Executions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			progView.OnScenarioStart(scenarioID)
+			progView.OnScenarioEnd(scenarioID)
+		}()
+	}
+	wg.Wait()
+
+	progressInfo := progView.progressInfoByID[scenarioID]
+	assert.Equal(t, spec.Executions, progressInfo.executions)
+	assert.Empty(t, progressInfo.startTimes)
+	assert.Equal(t, time.Duration(0), progView.calculateETA())
+
+	progView.OnBenchmarkEnd()
+}
+
+func Test_progressInfo_endExecution(t *testing.T) {
+	pi := minimalProgressInfo{}
+	assert.Equal(t, time.Duration(0), pi.endExecution(), "no execution in progress")
+
+	pi.startExecution()
+	time.Sleep(time.Millisecond)
+	pi.startExecution()
+
+	first := pi.endExecution()
+	second := pi.endExecution()
+	assert.True(t, first > second, "executions are expected to end in the order they started")
+	assert.Empty(t, pi.startTimes)
+}
+
 func TestProgressViewStartStateContract(t *testing.T) {
 	testProgressViewStartStateContract(
 		t,